@@ -5,6 +5,7 @@ package funcs
 import (
 	"fmt"
 	"reflect"
+	"unicode"
 )
 
 const (
@@ -391,3 +392,38 @@ func IsNilable(val interface{}) bool {
 	k := rv.Type().Kind()
 	return (k >= reflect.Chan) && (k <= reflect.Slice)
 }
+
+var (
+	runeTyp = reflect.TypeOf(rune(0))
+)
+
+// toRune converts val to a rune the same way as Iter.RuneValue.
+// Panics with a ConversionError if val is not convertible to a rune.
+func toRune(val interface{}) rune {
+	return rune(ConvertValue(val, runeTyp).Int())
+}
+
+// IsDigit is a func(interface{}) bool that returns true if val converted to a rune is a decimal digit.
+// Panics with a ConversionError if val is not convertible to a rune.
+func IsDigit(val interface{}) bool {
+	return unicode.IsDigit(toRune(val))
+}
+
+// IsLetter is a func(interface{}) bool that returns true if val converted to a rune is a letter.
+// Panics with a ConversionError if val is not convertible to a rune.
+func IsLetter(val interface{}) bool {
+	return unicode.IsLetter(toRune(val))
+}
+
+// IsWhitespace is a func(interface{}) bool that returns true if val converted to a rune is a whitespace character.
+// Panics with a ConversionError if val is not convertible to a rune.
+func IsWhitespace(val interface{}) bool {
+	return unicode.IsSpace(toRune(val))
+}
+
+// IsAlphanumeric is a func(interface{}) bool that returns true if val converted to a rune is a letter or decimal digit.
+// Panics with a ConversionError if val is not convertible to a rune.
+func IsAlphanumeric(val interface{}) bool {
+	r := toRune(val)
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
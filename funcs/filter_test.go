@@ -3,6 +3,7 @@
 package funcs
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -198,3 +199,57 @@ func TestFilter(t *testing.T) {
 		assert.Fail(t, "must panic")
 	}()
 }
+
+func TestRunePredicates(t *testing.T) {
+	// IsDigit
+	assert.True(t, IsDigit('0'))
+	assert.True(t, IsDigit(int32('9')))
+	assert.True(t, IsDigit('٣'))
+	assert.False(t, IsDigit('a'))
+	assert.False(t, IsDigit(' '))
+
+	// IsLetter
+	assert.True(t, IsLetter('a'))
+	assert.True(t, IsLetter('Z'))
+	assert.True(t, IsLetter('é'))
+	assert.True(t, IsLetter(byte('b')))
+	assert.False(t, IsLetter('1'))
+	assert.False(t, IsLetter('-'))
+
+	// IsWhitespace
+	assert.True(t, IsWhitespace(' '))
+	assert.True(t, IsWhitespace('\t'))
+	assert.True(t, IsWhitespace('\n'))
+	assert.True(t, IsWhitespace(' '))
+	assert.False(t, IsWhitespace('a'))
+
+	// IsAlphanumeric
+	assert.True(t, IsAlphanumeric('a'))
+	assert.True(t, IsAlphanumeric('é'))
+	assert.True(t, IsAlphanumeric('5'))
+	assert.False(t, IsAlphanumeric('_'))
+	assert.False(t, IsAlphanumeric(' '))
+
+	// Usable as filters
+	isLetter := Filter(IsLetter)
+	assert.True(t, isLetter('x'))
+
+	// Not convertible to a rune
+	func() {
+		defer func() {
+			assert.Equal(t, ConversionError{Value: "1", TargetType: reflect.TypeOf(rune(0)), Cause: CauseIncompatibleType}, recover())
+		}()
+
+		IsDigit("1")
+		assert.Fail(t, "must panic")
+	}()
+
+	func() {
+		defer func() {
+			assert.Equal(t, ConversionError{TargetType: reflect.TypeOf(rune(0)), Cause: CauseNilValue}, recover())
+		}()
+
+		IsDigit(nil)
+		assert.Fail(t, "must panic")
+	}()
+}
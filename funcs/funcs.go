@@ -3,10 +3,13 @@
 package funcs
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"math/cmplx"
 	"reflect"
+	"sync"
+	"time"
 )
 
 const (
@@ -20,6 +23,19 @@ const (
 	sortErrorMsg       = "fn must be a non-nil function of two arguments of the same type and return bool"
 )
 
+// timer is the subset of *time.Timer that Debounce uses
+type timer interface {
+	Stop() bool
+}
+
+var (
+	// now returns the current time for Throttle, tests may replace it
+	now = time.Now
+
+	// afterFunc calls f in its own goroutine after d has elapsed for Debounce, tests may replace it
+	afterFunc = func(d time.Duration, f func()) timer { return time.AfterFunc(d, f) }
+)
+
 // IndexOf returns the first of the following given an array or slice, index, and optional default value:
 // 1. slice[index] if the array or slice length > index
 // 2. default value if provided, converted to array or slice element type
@@ -58,6 +74,28 @@ func IndexOf(arrslc interface{}, index uint, defalt ...interface{}) interface{}
 	return reflect.Zero(elementTyp).Interface()
 }
 
+// IndexOfOk returns (arrslc[index], true) if the index exists, else (zero value of array or slice element type, false).
+// Unlike IndexOf, a zero value that exists can be distinguished from an index that does not exist.
+// Panics if arrslc is not an array or slice.
+func IndexOfOk(arrslc interface{}, index uint) (interface{}, bool) {
+	rv := reflect.ValueOf(arrslc)
+	switch rv.Kind() {
+	case reflect.Array:
+	case reflect.Slice:
+	default:
+		panic(indexOfErrorMsg)
+	}
+
+	// Return index if it exists
+	idx := int(index)
+	if rv.Len() > idx {
+		return rv.Index(idx).Interface(), true
+	}
+
+	// Else return zero value of array or slice element type
+	return reflect.Zero(rv.Type().Elem()).Interface(), false
+}
+
 // ValueOfKey returns the first of the following:
 // 1. map[key] if the key exists in the map
 // 2. default if provided
@@ -94,6 +132,26 @@ func ValueOfKey(mp interface{}, key interface{}, defalt ...interface{}) interfac
 	return reflect.Zero(elementTyp).Interface()
 }
 
+// ValueOfKeyOk returns (mp[key], true) if the key exists, else (zero value of map value type, false).
+// Unlike ValueOfKey, a zero value that exists can be distinguished from a key that does not exist.
+// Panics if mp is not a map.
+func ValueOfKeyOk(mp interface{}, key interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(mp)
+	if rv.Kind() != reflect.Map {
+		panic(valueOfKeyErrorMsg)
+	}
+
+	// Return key value if it exists
+	for mr := rv.MapRange(); mr.Next(); {
+		if mr.Key().Interface() == key {
+			return mr.Value().Interface(), true
+		}
+	}
+
+	// Else return zero value of map value type
+	return reflect.Zero(rv.Type().Elem()).Interface(), false
+}
+
 // Map (fn) adapts a func(any) any into a func(interface{}) interface{}.
 // If fn happens to be a func(interface{}) interface{}, it is returned as is.
 // Otherwise, each invocation converts the arg passed to the type the func receives.
@@ -192,6 +250,49 @@ func MapTo(fn interface{}, val interface{}) interface{} {
 	).Interface()
 }
 
+// Conversion error causes
+const (
+	CauseNilValue         = "nil value"
+	CauseIncompatibleType = "incompatible type"
+)
+
+// ConversionError is the panic value of ConvertValue, and of the funcs and methods that use it to convert a value to a specific type,
+// such as iter.Iter.IntValue, when the value cannot be converted.
+// Callers can recover the panic and type assert it to a ConversionError to examine the failed conversion.
+type ConversionError struct {
+	Value      interface{}
+	TargetType reflect.Type
+	Cause      string
+}
+
+// Error is the error interface
+func (e ConversionError) Error() string {
+	return fmt.Sprintf("cannot convert %v of type %T to %s: %s", e.Value, e.Value, e.TargetType, e.Cause)
+}
+
+// ConvertValue converts the given value to the given type.
+// Panics with a ConversionError if the value is nil or not convertible to the type.
+// Convert can still panic when ConvertibleTo is true, such as converting a slice to an array when the slice is too short,
+// so any such panic is also reported as a ConversionError.
+func ConvertValue(value interface{}, typ reflect.Type) reflect.Value {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		panic(ConversionError{Value: value, TargetType: typ, Cause: CauseNilValue})
+	}
+
+	if !rv.Type().ConvertibleTo(typ) {
+		panic(ConversionError{Value: value, TargetType: typ, Cause: CauseIncompatibleType})
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			panic(ConversionError{Value: value, TargetType: typ, Cause: CauseIncompatibleType})
+		}
+	}()
+
+	return rv.Convert(typ)
+}
+
 // ConvertTo generates a func(interface{}) interface{} that converts a value into the same type as the value passed.
 // Eg, ConvertTo(int8(0)) converts a func that converts a value into an int8.
 func ConvertTo(out interface{}) func(interface{}) interface{} {
@@ -202,6 +303,20 @@ func ConvertTo(out interface{}) func(interface{}) interface{} {
 	}
 }
 
+// TryConvert attempts to convert val into the same type as example.
+// Returns (converted value, true) if the conversion succeeds, or (nil, false) if val is not convertible.
+// Unlike ConvertTo, TryConvert does not panic.
+func TryConvert(val, example interface{}) (converted interface{}, ok bool) {
+	defer func() {
+		if recover() != nil {
+			converted, ok = nil, false
+		}
+	}()
+
+	converted, ok = reflect.ValueOf(val).Convert(reflect.TypeOf(example)).Interface(), true
+	return
+}
+
 // Supplier (fn) adapts a func() any into a func() interface{}.
 // If fn happens to be a func() interface{}, it is returned as is.
 // fn may have a single variadic argument.
@@ -325,6 +440,65 @@ func Consumer(fn interface{}) func(interface{}) {
 	}
 }
 
+// Debounce returns a func(interface{}) that only invokes fn once d has elapsed since it was last called.
+// Each call cancels the pending invocation of the previous call, if any, so a rapid series of calls collapses into a single
+// invocation of fn with the argument of the last call.
+// fn is invoked in its own goroutine.
+func Debounce(d time.Duration, fn func(interface{})) func(interface{}) {
+	var (
+		mu      sync.Mutex
+		pending timer
+	)
+
+	return func(arg interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if pending != nil {
+			pending.Stop()
+		}
+
+		pending = afterFunc(d, func() { fn(arg) })
+	}
+}
+
+// Throttle returns a func(interface{}) that invokes fn at most once per interval.
+// The first call invokes fn immediately, and starts an interval during which further calls are ignored.
+// The next call after the interval has elapsed invokes fn, and starts a new interval.
+func Throttle(interval time.Duration, fn func(interface{})) func(interface{}) {
+	var (
+		mu      sync.Mutex
+		last    time.Time
+		invoked bool
+	)
+
+	return func(arg interface{}) {
+		mu.Lock()
+		current := now()
+		if invoked && (current.Sub(last) < interval) {
+			mu.Unlock()
+			return
+		}
+
+		last, invoked = current, true
+		mu.Unlock()
+
+		fn(arg)
+	}
+}
+
+// ApplyN returns the result of applying f n times to seed, EG ApplyN(2, f, seed) returns f(f(seed)).
+// If n <= 0, seed is returned as is.
+// Unlike stream.Iterate, only the final value is returned, not the series of values.
+func ApplyN(n int, f func(interface{}) interface{}, seed interface{}) interface{} {
+	result := seed
+	for i := 0; i < n; i++ {
+		result = f(result)
+	}
+
+	return result
+}
+
 // Ternary returns trueVal if expr is true, else it returns falseVal
 func Ternary(expr bool, trueVal, falseVal interface{}) interface{} {
 	if expr {
@@ -376,6 +550,30 @@ func PanicVBM(val interface{}, valid bool, msg string) interface{} {
 	return val
 }
 
+// SafeRun runs f, and returns (result of f, nil) if it does not panic.
+// If f panics, the panic is recovered and returned as (nil, error):
+// - an error value, such as an iter.ConversionError, is returned as is, so it can be type asserted
+// - a string value is returned as an error with the string as the message
+// - any other value is returned as an error with a message of fmt.Sprint(value)
+// This provides a single boundary for converting the panics of a whole pipeline into an error.
+func SafeRun(f func() interface{}) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch rt := r.(type) {
+			case error:
+				err = rt
+			case string:
+				err = errors.New(rt)
+			default:
+				err = fmt.Errorf("%v", rt)
+			}
+		}
+	}()
+
+	result = f()
+	return
+}
+
 // SortFunc adapts a func(val1, val2 any) bool into a func(val1, val2 interface{}) bool.
 // If fn is already a func(val1, val2 interface{}) bool, it is returned as is.
 // The passed func must return true if and only if val1 < val2.
@@ -452,3 +650,53 @@ var (
 		return val1.Cmp(val2) == -1
 	})
 )
+
+// ComparingBy returns a func(val1, val2 interface{}) bool that compares two values by comparing keys extracted from them.
+// keyFn extracts a key from each value, and keyLess must return true if and only if key1 < key2.
+// EG, ComparingBy(func(p interface{}) interface{} { return p.(Person).ID }, IntSortFunc) sorts people by ID.
+func ComparingBy(
+	keyFn func(val interface{}) (key interface{}),
+	keyLess func(key1, key2 interface{}) bool,
+) func(val1, val2 interface{}) bool {
+	return func(val1, val2 interface{}) bool {
+		return keyLess(keyFn(val1), keyFn(val2))
+	}
+}
+
+// NilsFirst wraps a comparator so that nil values (according to IsNil) sort before all non-nil values.
+// The comparator is only invoked when both values are non-nil, so it does not have to handle nils.
+func NilsFirst(less func(val1, val2 interface{}) bool) func(val1, val2 interface{}) bool {
+	return func(val1, val2 interface{}) bool {
+		switch nil1, nil2 := IsNil(val1), IsNil(val2); {
+		case nil1:
+			return !nil2
+		case nil2:
+			return false
+		default:
+			return less(val1, val2)
+		}
+	}
+}
+
+// NilsLast wraps a comparator so that nil values (according to IsNil) sort after all non-nil values.
+// The comparator is only invoked when both values are non-nil, so it does not have to handle nils.
+func NilsLast(less func(val1, val2 interface{}) bool) func(val1, val2 interface{}) bool {
+	return func(val1, val2 interface{}) bool {
+		switch nil1, nil2 := IsNil(val1), IsNil(val2); {
+		case nil2:
+			return !nil1
+		case nil1:
+			return false
+		default:
+			return less(val1, val2)
+		}
+	}
+}
+
+// Reverse wraps a comparator to sort in the reverse order, so that values the comparator sorts last are sorted first.
+// EG, Reverse(IntSortFunc) sorts ints in descending order.
+func Reverse(less func(val1, val2 interface{}) bool) func(val1, val2 interface{}) bool {
+	return func(val1, val2 interface{}) bool {
+		return less(val2, val1)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"reflect"
+	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -57,6 +60,32 @@ func TestIndexOf(t *testing.T) {
 	}()
 }
 
+func TestIndexOfOk(t *testing.T) {
+	// Present zero value
+	val, ok := IndexOfOk([]int{0}, 0)
+	assert.Equal(t, 0, val)
+	assert.True(t, ok)
+
+	// Missing index
+	val, ok = IndexOfOk([]int{0}, 1)
+	assert.Equal(t, 0, val)
+	assert.False(t, ok)
+
+	// Array
+	val, ok = IndexOfOk([1]string{"a"}, 0)
+	assert.Equal(t, "a", val)
+	assert.True(t, ok)
+
+	func() {
+		defer func() {
+			assert.Equal(t, indexOfErrorMsg, recover())
+		}()
+
+		IndexOfOk(0, 0)
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestValueOfKey(t *testing.T) {
 	// Key exists
 	assert.Equal(t, 1, ValueOfKey(map[string]int{"1": 1}, "1"))
@@ -84,6 +113,27 @@ func TestValueOfKey(t *testing.T) {
 	}()
 }
 
+func TestValueOfKeyOk(t *testing.T) {
+	// Present zero value
+	val, ok := ValueOfKeyOk(map[string]int{"0": 0}, "0")
+	assert.Equal(t, 0, val)
+	assert.True(t, ok)
+
+	// Missing key
+	val, ok = ValueOfKeyOk(map[string]int{"0": 0}, "1")
+	assert.Equal(t, 0, val)
+	assert.False(t, ok)
+
+	func() {
+		defer func() {
+			assert.Equal(t, valueOfKeyErrorMsg, recover())
+		}()
+
+		ValueOfKeyOk(0, 0)
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestMap(t *testing.T) {
 	// Exact match
 	mapFn := Map(func(i interface{}) interface{} { return i.(int) * 2 })
@@ -198,11 +248,50 @@ func TestMapTo(t *testing.T) {
 	}()
 }
 
+func TestConvertValue(t *testing.T) {
+	assert.Equal(t, int8(1), ConvertValue(1, reflect.TypeOf(int8(0))).Interface())
+
+	func() {
+		defer func() {
+			err, isa := recover().(ConversionError)
+			assert.True(t, isa)
+			assert.Equal(t, ConversionError{Value: "a", TargetType: reflect.TypeOf(0), Cause: CauseIncompatibleType}, err)
+			assert.Equal(t, "cannot convert a of type string to int: incompatible type", err.Error())
+		}()
+
+		ConvertValue("a", reflect.TypeOf(0))
+		assert.Fail(t, "must panic")
+	}()
+
+	func() {
+		defer func() {
+			assert.Equal(t, ConversionError{TargetType: reflect.TypeOf(0), Cause: CauseNilValue}, recover())
+		}()
+
+		ConvertValue(nil, reflect.TypeOf(0))
+		assert.Fail(t, "must panic")
+	}()
+}
+
 func TestConvertTo(t *testing.T) {
 	convertFn := ConvertTo(int8(0))
 	assert.Equal(t, int8(1), convertFn(1))
 }
 
+func TestTryConvert(t *testing.T) {
+	val, ok := TryConvert(1, int8(0))
+	assert.Equal(t, int8(1), val)
+	assert.True(t, ok)
+
+	val, ok = TryConvert("1", 0)
+	assert.Nil(t, val)
+	assert.False(t, ok)
+
+	val, ok = TryConvert(nil, 0)
+	assert.Nil(t, val)
+	assert.False(t, ok)
+}
+
 func TestSupplier(t *testing.T) {
 	// Exact match
 	supplierFn := Supplier(func() interface{} { return 2 })
@@ -394,6 +483,99 @@ func TestConsumer(t *testing.T) {
 	}()
 }
 
+// mockTimer is a timer that only fires when the test says so
+type mockTimer struct {
+	f       func()
+	stopped bool
+}
+
+func (t *mockTimer) Stop() bool {
+	wasStopped := t.stopped
+	t.stopped = true
+	return !wasStopped
+}
+
+func TestDebounce(t *testing.T) {
+	var (
+		timers []*mockTimer
+		fire   = func() {
+			for _, timer := range timers {
+				if !timer.stopped {
+					timer.stopped = true
+					timer.f()
+				}
+			}
+		}
+		calls   []interface{}
+		durs    []time.Duration
+		debFunc = Debounce(time.Second, func(arg interface{}) { calls = append(calls, arg) })
+	)
+
+	origAfterFunc := afterFunc
+	defer func() { afterFunc = origAfterFunc }()
+
+	afterFunc = func(d time.Duration, f func()) timer {
+		timer := &mockTimer{f: f}
+		timers = append(timers, timer)
+		durs = append(durs, d)
+		return timer
+	}
+
+	// Rapid calls collapse into one call with the last arg
+	debFunc(1)
+	debFunc(2)
+	debFunc(3)
+	assert.Nil(t, calls)
+	fire()
+	assert.Equal(t, []interface{}{3}, calls)
+	assert.Equal(t, []time.Duration{time.Second, time.Second, time.Second}, durs)
+
+	// A call after the delay has elapsed is invoked separately
+	debFunc(4)
+	fire()
+	assert.Equal(t, []interface{}{3, 4}, calls)
+}
+
+func TestThrottle(t *testing.T) {
+	var (
+		start   = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		elapsed time.Duration
+		calls   []interface{}
+		thrFunc = Throttle(time.Second, func(arg interface{}) { calls = append(calls, arg) })
+	)
+
+	now = func() time.Time { return start.Add(elapsed) }
+	defer func() { now = time.Now }()
+
+	// Rapid calls only invoke the first
+	thrFunc(1)
+	elapsed = 500 * time.Millisecond
+	thrFunc(2)
+	elapsed = 999 * time.Millisecond
+	thrFunc(3)
+	assert.Equal(t, []interface{}{1}, calls)
+
+	// Next call after the interval is invoked, and starts a new interval
+	elapsed = time.Second
+	thrFunc(4)
+	elapsed = 1500 * time.Millisecond
+	thrFunc(5)
+	elapsed = 2500 * time.Millisecond
+	thrFunc(6)
+	assert.Equal(t, []interface{}{1, 4, 6}, calls)
+}
+
+func TestApplyN(t *testing.T) {
+	increment := func(val interface{}) interface{} { return val.(int) + 1 }
+	assert.Equal(t, 3, ApplyN(3, increment, 0))
+	assert.Equal(t, 5, ApplyN(0, increment, 5))
+	assert.Equal(t, 5, ApplyN(-1, increment, 5))
+
+	// Newton's method for the square root of 2
+	sqrt2 := func(val interface{}) interface{} { x := val.(float64); return x - (x*x-2)/(2*x) }
+	assert.InDelta(t, 1.41421356, ApplyN(5, sqrt2, 1.0), 1e-8)
+}
+
 func TestTernary(t *testing.T) {
 	assert.Equal(t, 1, Ternary(true, 1, 2))
 	assert.Equal(t, 2, Ternary(false, 1, 2))
@@ -454,6 +636,41 @@ func TestPanic(t *testing.T) {
 	}()
 }
 
+// safeRunError is an error type for testing SafeRun preserves error panics
+type safeRunError struct {
+	val int
+}
+
+func (e safeRunError) Error() string {
+	return fmt.Sprintf("bad value %d", e.val)
+}
+
+func TestSafeRun(t *testing.T) {
+	// No panic
+	result, err := SafeRun(func() interface{} { return 1 })
+	assert.Equal(t, 1, result)
+	assert.Nil(t, err)
+
+	// String panic
+	result, err = SafeRun(func() interface{} { panic("oops") })
+	assert.Nil(t, result)
+	assert.Equal(t, "oops", err.Error())
+
+	// Error panic is returned as is
+	result, err = SafeRun(func() interface{} { panic(safeRunError{2}) })
+	assert.Nil(t, result)
+	assert.Equal(t, safeRunError{2}, err)
+
+	// Other panic
+	result, err = SafeRun(func() interface{} { panic(3) })
+	assert.Nil(t, result)
+	assert.Equal(t, "3", err.Error())
+
+	// Panics of funcs in this package
+	_, err = SafeRun(func() interface{} { return SortFunc(nil) })
+	assert.Equal(t, sortErrorMsg, err.Error())
+}
+
 func TestSortFunc(t *testing.T) {
 	sf := SortFunc(func(val1, val2 int) bool { return val1 < val2 })
 	assert.True(t, sf(1, 2))
@@ -503,3 +720,69 @@ func TestSortFunc(t *testing.T) {
 	assert.True(t, sf(big.NewFloat(1.0), big.NewFloat(2.0)))
 	assert.False(t, sf(big.NewFloat(2.0), big.NewFloat(1.0)))
 }
+
+func TestComparingBy(t *testing.T) {
+	type person struct {
+		ID   int
+		Name string
+	}
+
+	var (
+		getID   = func(p interface{}) interface{} { return p.(person).ID }
+		getName = func(p interface{}) interface{} { return p.(person).Name }
+		people  = []interface{}{person{3, "Alice"}, person{1, "Carol"}, person{2, "Bob"}}
+	)
+
+	byID := ComparingBy(getID, IntSortFunc)
+	assert.True(t, byID(person{1, "Carol"}, person{2, "Bob"}))
+	assert.False(t, byID(person{2, "Bob"}, person{1, "Carol"}))
+
+	sort.Slice(people, func(i, j int) bool { return byID(people[i], people[j]) })
+	assert.Equal(t, []interface{}{person{1, "Carol"}, person{2, "Bob"}, person{3, "Alice"}}, people)
+
+	byName := ComparingBy(getName, StringSortFunc)
+	sort.Slice(people, func(i, j int) bool { return byName(people[i], people[j]) })
+	assert.Equal(t, []interface{}{person{3, "Alice"}, person{2, "Bob"}, person{1, "Carol"}}, people)
+}
+
+func TestNilsFirstNilsLast(t *testing.T) {
+	var (
+		ptr    = func(i int) *int { return &i }
+		intPtr = func(val1, val2 interface{}) bool { return *(val1.(*int)) < *(val2.(*int)) }
+	)
+
+	// Ints with nil interfaces
+	data := []interface{}{3, nil, 1, nil, 2}
+	nilsFirst := NilsFirst(IntSortFunc)
+	sort.SliceStable(data, func(i, j int) bool { return nilsFirst(data[i], data[j]) })
+	assert.Equal(t, []interface{}{nil, nil, 1, 2, 3}, data)
+
+	nilsLast := NilsLast(IntSortFunc)
+	sort.SliceStable(data, func(i, j int) bool { return nilsLast(data[i], data[j]) })
+	assert.Equal(t, []interface{}{1, 2, 3, nil, nil}, data)
+
+	// Typed nil pointers
+	var nilPtr *int
+	data = []interface{}{ptr(2), nilPtr, ptr(1)}
+	nilsFirst = NilsFirst(intPtr)
+	sort.SliceStable(data, func(i, j int) bool { return nilsFirst(data[i], data[j]) })
+	assert.Equal(t, []interface{}{nilPtr, ptr(1), ptr(2)}, data)
+
+	nilsLast = NilsLast(intPtr)
+	sort.SliceStable(data, func(i, j int) bool { return nilsLast(data[i], data[j]) })
+	assert.Equal(t, []interface{}{ptr(1), ptr(2), nilPtr}, data)
+
+	// Two nils are equal
+	assert.False(t, NilsFirst(IntSortFunc)(nil, nil))
+	assert.False(t, NilsLast(IntSortFunc)(nil, nil))
+}
+
+func TestReverse(t *testing.T) {
+	data := []interface{}{2, 3, 1}
+	desc := Reverse(IntSortFunc)
+	sort.Slice(data, func(i, j int) bool { return desc(data[i], data[j]) })
+	assert.Equal(t, []interface{}{3, 2, 1}, data)
+
+	// Equal values remain equal
+	assert.False(t, desc(1, 1))
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package funcs
+
+// Pipeline builds a single reusable function that applies a series of map and filter steps to one value.
+// It is a lightweight alternative to a stream when only single value logic is needed.
+// The zero value is ready to use.
+type Pipeline struct {
+	steps []func(interface{}) (interface{}, bool)
+}
+
+// NewPipeline constructs a new Pipeline
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+// Map adds a step that maps the value to a new value, possibly of a different type.
+// fn must be a func(any) any, it is adapted using Map.
+func (p *Pipeline) Map(fn interface{}) *Pipeline {
+	mapFn := Map(fn)
+
+	p.steps = append(p.steps, func(val interface{}) (interface{}, bool) {
+		return mapFn(val), true
+	})
+
+	return p
+}
+
+// Filter adds a step that drops the value if it does not pass the predicate, skipping any further steps.
+// fn must be a func(any) bool, it is adapted using Filter.
+func (p *Pipeline) Filter(fn interface{}) *Pipeline {
+	filterFn := Filter(fn)
+
+	p.steps = append(p.steps, func(val interface{}) (interface{}, bool) {
+		return val, filterFn(val)
+	})
+
+	return p
+}
+
+// Build returns a func that applies the steps added so far in order.
+// The func returns (result, true) if the value passed every filter step, else (nil, false).
+// Steps added to the Pipeline after Build is called do not affect the returned func.
+func (p *Pipeline) Build() func(interface{}) (interface{}, bool) {
+	steps := make([]func(interface{}) (interface{}, bool), len(p.steps))
+	copy(steps, p.steps)
+
+	return func(val interface{}) (interface{}, bool) {
+		for _, step := range steps {
+			var keep bool
+			if val, keep = step(val); !keep {
+				return nil, false
+			}
+		}
+
+		return val, true
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package funcs
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	// Empty pipeline returns values as is
+	fn := NewPipeline().Build()
+	val, keep := fn(1)
+	assert.Equal(t, 1, val)
+	assert.True(t, keep)
+
+	// Multiply by 3, keep evens, convert to string
+	p := NewPipeline().
+		Map(func(i int) int { return i * 3 }).
+		Filter(func(i int) bool { return i%2 == 0 }).
+		Map(strconv.Itoa)
+	fn = p.Build()
+
+	val, keep = fn(2)
+	assert.Equal(t, "6", val)
+	assert.True(t, keep)
+
+	val, keep = fn(3)
+	assert.Nil(t, val)
+	assert.False(t, keep)
+
+	// Steps added after Build do not affect a previously built func
+	p.Filter(func(s string) bool { return false })
+	val, keep = fn(4)
+	assert.Equal(t, "12", val)
+	assert.True(t, keep)
+
+	val, keep = p.Build()(4)
+	assert.Nil(t, val)
+	assert.False(t, keep)
+
+	// Zero value is ready to use
+	var zp Pipeline
+	val, keep = zp.Map(func(i int) int { return i + 1 }).Build()(1)
+	assert.Equal(t, 2, val)
+	assert.True(t, keep)
+
+	// Invalid funcs
+	func() {
+		defer func() {
+			assert.Equal(t, mapErrorMsg, recover())
+		}()
+
+		NewPipeline().Map(1)
+		assert.Fail(t, "Must panic")
+	}()
+
+	func() {
+		defer func() {
+			assert.Equal(t, filterErrorMsg, recover())
+		}()
+
+		NewPipeline().Filter(func(i int) int { return i })
+		assert.Fail(t, "Must panic")
+	}()
+}
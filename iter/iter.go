@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sync"
+	"time"
+
+	"github.com/bantling/gomicro/funcs"
 )
 
 // Error constants
@@ -19,12 +23,28 @@ const (
 	ErrRowsGreaterThanZero              = "rows must be > 0"
 	ErrIterableGeneratorCannotBeNil     = "Iterable.Generator cannot be nil"
 	ErrIterableGeneratorCannotReturnNil = "Iterable.Generator cannot return a nil iterating function"
+	ErrWindowGreaterThanZero            = "window must be > 0"
+)
+
+// Conversion error causes, see funcs.ConversionError
+const (
+	CauseNilValue         = funcs.CauseNilValue
+	CauseIncompatibleType = funcs.CauseIncompatibleType
 )
 
 var (
 	zeroUTF8Buffer = []byte{0, 0, 0, 0}
+
+	// now returns the current time for time based methods like BatchByTime, tests may replace it
+	now = time.Now
 )
 
+// ConversionError is the panic value of the Iter methods that convert a value to a specific type, such as IntValue,
+// when the value cannot be converted.
+// Callers can recover the panic and type assert it to a ConversionError to examine the failed conversion.
+// It is the same type as funcs.ConversionError, so that conversions in both packages panic with the same type.
+type ConversionError = funcs.ConversionError
+
 // Iter is an iterator of values of an arbitrary type.
 // Technically, the values can be different types, but that is usually undesirable.
 type Iter struct {
@@ -32,6 +52,8 @@ type Iter struct {
 	nextCalled bool
 	value      interface{}
 	buffer     []interface{}
+	// remaining returns the number of values iter has not returned yet, if known
+	remaining func() int
 }
 
 // New constructs an Iter from an iterating function.
@@ -49,7 +71,13 @@ func New(iter func() (interface{}, bool)) *Iter {
 // Of constructs an Iter that iterates the items passed.
 // If any item is an array/slice/map/Iterable, it will be handled the same as any other type - the whole array/slice/map/Iterable will iterated as a single value.
 func Of(items ...interface{}) *Iter {
-	return New(ArraySliceIterFunc(reflect.ValueOf(items)))
+	return ofArraySlice(reflect.ValueOf(items))
+}
+
+// ofArraySlice constructs an Iter that iterates an array or slice using an ArraySliceIterFunc, that knows its Len.
+func ofArraySlice(arraySlice reflect.Value) *Iter {
+	iterFunc, remaining := arraySliceIterFunc(arraySlice)
+	return &Iter{iter: iterFunc, remaining: remaining}
 }
 
 // OfFlatten constructs an Iter that flattens a multi-dimensional array or slice into a new one-dimensional slice.
@@ -60,7 +88,7 @@ func OfFlatten(items interface{}) *Iter {
 		return New(NoValueIterFunc)
 	}
 
-	return New(ArraySliceIterFunc(reflect.ValueOf(FlattenArraySlice(items))))
+	return ofArraySlice(reflect.ValueOf(FlattenArraySlice(items)))
 }
 
 // OfElements constructs an Iter that iterates the elements of the item passed.
@@ -71,7 +99,18 @@ func OfElements(item interface{}) *Iter {
 		return New(NoValueIterFunc)
 	}
 
-	return New(ElementsIterFunc(reflect.ValueOf(item)))
+	rItem := reflect.ValueOf(item)
+	if kind := rItem.Kind(); (kind == reflect.Array) || (kind == reflect.Slice) {
+		return ofArraySlice(rItem)
+	}
+
+	return New(ElementsIterFunc(rItem))
+}
+
+// OfMapSorted constructs an Iter that iterates the entries of a map as KeyValue instances, in ascending key order according to keyLess.
+// See SortedMapIterFunc for details.
+func OfMapSorted(m interface{}, keyLess func(key1, key2 interface{}) bool) *Iter {
+	return New(SortedMapIterFunc(reflect.ValueOf(m), keyLess))
 }
 
 // OfReader constructs an Iter that iterates the bytes of a reader.
@@ -92,6 +131,185 @@ func OfReaderLines(src io.Reader) *Iter {
 	return New(ReaderToLinesIterFunc(src))
 }
 
+// OfReaderWords constructs an Iter that iterates the words of a reader.
+// The optional isSep func determines which runes separate words, the default is unicode.IsSpace.
+// See ReaderToWordsIterFunc for details.
+func OfReaderWords(src io.Reader, isSep ...func(rune) bool) *Iter {
+	return New(ReaderToWordsIterFunc(src, isSep...))
+}
+
+// OfReaderErr constructs an Iter that iterates the bytes of a reader, and a function that returns the last read error.
+// Unlike OfReader, a read error other than io.EOF does not cause a panic - the Iter simply ends, and the error function returns the error.
+// The error function returns nil if no error has occurred, or the reader reached EOF.
+// A byte returned by the reader along with an error is iterated before the Iter ends.
+func OfReaderErr(src io.Reader) (*Iter, func() error) {
+	var (
+		buf = make([]byte, 1)
+		err error
+	)
+
+	it := New(func() (interface{}, bool) {
+		for err == nil {
+			var n int
+			if n, err = src.Read(buf); n > 0 {
+				return buf[0], true
+			}
+		}
+
+		return nil, false
+	})
+
+	return it, func() error {
+		if err == io.EOF {
+			return nil
+		}
+
+		return err
+	}
+}
+
+// OfChannel constructs an Iter that iterates the values received from a channel until it is closed.
+// See ChannelIterFunc for details.
+func OfChannel(ch interface{}) *Iter {
+	return New(ChannelIterFunc(reflect.ValueOf(ch)))
+}
+
+// OfChannelWithDone constructs an Iter that iterates the values received from a channel,
+// and a stop function that signals the producer no more values are wanted by closing the done channel.
+//
+// The cancellation protocol is cooperative:
+// - The producer sends values on ch, and selects on done so that it stops sending and returns once done is closed.
+// - The consumer calls stop when it has finished reading, typically via defer, whether or not ch has been exhausted.
+// - The Iter calls stop itself if it reads that ch has been closed.
+// - Once stop is called, the Iter is exhausted, even if it is blocked waiting for a value from ch.
+//
+// It is safe to call stop any number of times, from any goroutine.
+// Panics if ch is not a channel that can be received from.
+func OfChannelWithDone(ch interface{}, done chan<- struct{}) (*Iter, func()) {
+	var (
+		chVal = reflect.ValueOf(ch)
+		quit  = make(chan struct{})
+		once  sync.Once
+		stop  = func() {
+			once.Do(func() {
+				close(quit)
+				close(done)
+			})
+		}
+	)
+
+	if (chVal.Kind() != reflect.Chan) || (chVal.Type().ChanDir()&reflect.RecvDir == 0) {
+		panic(ErrChannelIterFuncArg)
+	}
+
+	// Receive from ch or quit, whichever is ready first
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: chVal},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(quit)},
+	}
+
+	return New(func() (interface{}, bool) {
+		chosen, val, haveIt := reflect.Select(cases)
+		if (chosen == 1) || !haveIt {
+			// Stopped by consumer, or channel has been closed
+			stop()
+			return nil, false
+		}
+
+		return val.Interface(), true
+	}), stop
+}
+
+// OfSeq constructs an Iter that iterates the values yielded by a Go range-over-func style sequence,
+// and a stop function that ends the sequence early.
+//
+// Since seq pushes values to yield, and an Iter pulls values, seq is run in a goroutine:
+//   - The goroutine is not started until the first call to Next, so an Iter that is never read does not start one.
+//   - Each call to Next resumes seq until it yields one value, so seq never runs ahead of the consumer.
+//   - The goroutine exits when seq returns, which happens naturally when the sequence is fully iterated.
+//   - The consumer calls stop when it has finished reading, typically via defer, whether or not the sequence has been exhausted.
+//     If the goroutine is running, stop causes yield to return false, and waits for seq to return, so that no goroutine is leaked.
+//   - Once stop is called, the Iter is exhausted.
+//   - If seq panics, the panic is recovered in the goroutine, and Next panics with the same value.
+//
+// It is safe to call stop any number of times, but not concurrently with Next.
+func OfSeq(seq func(yield func(interface{}) bool)) (*Iter, func()) {
+	var (
+		req      = make(chan bool)
+		values   = make(chan interface{})
+		panicVal interface{}
+		started  bool
+		done     bool
+		once     sync.Once
+		stop     = func() {
+			once.Do(func() {
+				if started && !done {
+					// Tell yield to return false, then wait for seq to return
+					req <- false
+					for range values {
+					}
+				}
+
+				done = true
+			})
+		}
+	)
+
+	run := func() {
+		defer close(values)
+		defer func() {
+			panicVal = recover()
+		}()
+
+		// Wait for the first request, in case stop is called before seq is run
+		if !<-req {
+			return
+		}
+
+		stopped := false
+		seq(func(value interface{}) bool {
+			// Ignore values yielded by a seq that keeps going after being told to stop
+			if stopped {
+				return false
+			}
+
+			values <- value
+			stopped = !<-req
+			return !stopped
+		})
+	}
+
+	return New(func() (interface{}, bool) {
+		if done {
+			return nil, false
+		}
+
+		if !started {
+			started = true
+			go run()
+		}
+
+		req <- true
+		value, haveIt := <-values
+		if !haveIt {
+			done = true
+			if panicVal != nil {
+				panic(panicVal)
+			}
+
+			return nil, false
+		}
+
+		return value, true
+	}), stop
+}
+
+// OfDirWalk constructs an Iter that iterates the paths of the files under a root directory, recursively.
+// See DirWalkIterFunc for details.
+func OfDirWalk(root string) *Iter {
+	return New(DirWalkIterFunc(root))
+}
+
 // Concat concatenates the provided Iters into a single new Iter that iterates the first iter, then the second, etc.
 // Any combination of empty and non-empty Iters are correctly iterated.
 func Concat(iters ...*Iter) *Iter {
@@ -171,18 +389,38 @@ func (it *Iter) Value() interface{} {
 	return it.value
 }
 
+// Len returns the number of values that have not been read yet, without reading them, if it is known.
+// It is known when the Iter was constructed by Of, OfFlatten, or OfElements of an array or slice, otherwise Len returns -1.
+// Values that are unread, or have been advanced to by Next but not read by Value, are included in the count.
+// Callers that need a count regardless should fall back to iterating the values when Len returns -1.
+func (it *Iter) Len() int {
+	switch {
+	case it.iter == nil:
+		return 0
+	case it.remaining == nil:
+		return -1
+	}
+
+	n := it.remaining() + len(it.buffer)
+	if it.nextCalled {
+		n++
+	}
+
+	return n
+}
+
 // ValueOfType reads the value and converts it to a value with the same type as the given value.
 // EG, if an int is passed, it converts the value to an int.
 // The result will have to be type asserted.
 // Panics is value is nil.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to the type of the given value.
+// Panics with a ConversionError if the value is not convertible to the type of the given value.
 func (it *Iter) ValueOfType(value interface{}) interface{} {
 	if value == nil {
 		panic(ErrValueCannotBeNil)
 	}
 
-	return reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(value)).Interface()
+	return funcs.ConvertValue(it.Value(), reflect.TypeOf(value)).Interface()
 }
 
 // NextValue retrieves the next value for cases where you know the iterator has another value.
@@ -201,9 +439,9 @@ func (it *Iter) NextValueOfType(value interface{}) interface{} {
 
 // BoolValue reads the value and converts it to a bool.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a bool.
+// Panics with a ConversionError if the value is not convertible to a bool.
 func (it *Iter) BoolValue() bool {
-	return reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(true)).Bool()
+	return funcs.ConvertValue(it.Value(), reflect.TypeOf(true)).Bool()
 }
 
 // NextBoolValue retrieves the next value as a bool for cases where you know the iterator has another value.
@@ -215,9 +453,9 @@ func (it *Iter) NextBoolValue() bool {
 
 // ByteValue reads the value and converts it to a byte.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a byte.
+// Panics with a ConversionError if the value is not convertible to a byte.
 func (it *Iter) ByteValue() byte {
-	return byte(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(byte(0))).Uint())
+	return byte(funcs.ConvertValue(it.Value(), reflect.TypeOf(byte(0))).Uint())
 }
 
 // NextByteValue retrieves the next value as a byte for cases where you know the iterator has another value.
@@ -229,9 +467,9 @@ func (it *Iter) NextByteValue() byte {
 
 // RuneValue reads the value and converts it to a rune.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a rune.
+// Panics with a ConversionError if the value is not convertible to a rune.
 func (it *Iter) RuneValue() rune {
-	return rune(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(rune(0))).Int())
+	return rune(funcs.ConvertValue(it.Value(), reflect.TypeOf(rune(0))).Int())
 }
 
 // NextRuneValue retrieves the next value as a rune for cases where you know the iterator has another value.
@@ -243,9 +481,9 @@ func (it *Iter) NextRuneValue() rune {
 
 // IntValue reads the value and converts it to an int.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to an int.
+// Panics with a ConversionError if the value is not convertible to an int.
 func (it *Iter) IntValue() int {
-	return int(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(0)).Int())
+	return int(funcs.ConvertValue(it.Value(), reflect.TypeOf(0)).Int())
 }
 
 // NextIntValue retrieves the next value as an int for cases where you know the iterator has another value.
@@ -257,9 +495,9 @@ func (it *Iter) NextIntValue() int {
 
 // Int8Value reads the value and converts it to an int8.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to an int8.
+// Panics with a ConversionError if the value is not convertible to an int8.
 func (it *Iter) Int8Value() int8 {
-	return int8(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(int8(0))).Int())
+	return int8(funcs.ConvertValue(it.Value(), reflect.TypeOf(int8(0))).Int())
 }
 
 // NextInt8Value retrieves the next value as an int8 for cases where you know the iterator has another value.
@@ -271,9 +509,9 @@ func (it *Iter) NextInt8Value() int8 {
 
 // Int16Value reads the value and converts it to an int16.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to an int16.
+// Panics with a ConversionError if the value is not convertible to an int16.
 func (it *Iter) Int16Value() int16 {
-	return int16(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(int16(0))).Int())
+	return int16(funcs.ConvertValue(it.Value(), reflect.TypeOf(int16(0))).Int())
 }
 
 // NextInt16Value retrieves the next value as an int16 for cases where you know the iterator has another value.
@@ -285,9 +523,9 @@ func (it *Iter) NextInt16Value() int16 {
 
 // Int32Value reads the value and converts it to an int32.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to an int32.
+// Panics with a ConversionError if the value is not convertible to an int32.
 func (it *Iter) Int32Value() int32 {
-	return int32(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(int32(0))).Int())
+	return int32(funcs.ConvertValue(it.Value(), reflect.TypeOf(int32(0))).Int())
 }
 
 // NextInt32Value retrieves the next value as an int32 for cases where you know the iterator has another value.
@@ -299,9 +537,9 @@ func (it *Iter) NextInt32Value() int32 {
 
 // Int64Value reads the value and converts it to an int64.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to an int64.
+// Panics with a ConversionError if the value is not convertible to an int64.
 func (it *Iter) Int64Value() int64 {
-	return reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(int64(0))).Int()
+	return funcs.ConvertValue(it.Value(), reflect.TypeOf(int64(0))).Int()
 }
 
 // NextInt64Value retrieves the next value as an int64 for cases where you know the iterator has another value.
@@ -313,9 +551,9 @@ func (it *Iter) NextInt64Value() int64 {
 
 // UintValue reads the value and converts it to a uint.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a uint.
+// Panics with a ConversionError if the value is not convertible to a uint.
 func (it *Iter) UintValue() uint {
-	return uint(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(uint(0))).Uint())
+	return uint(funcs.ConvertValue(it.Value(), reflect.TypeOf(uint(0))).Uint())
 }
 
 // NextUintValue retrieves the next value as a uint for cases where you know the iterator has another value.
@@ -327,9 +565,9 @@ func (it *Iter) NextUintValue() uint {
 
 // Uint8Value reads the value and converts it to a uint8.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a uint8.
+// Panics with a ConversionError if the value is not convertible to a uint8.
 func (it *Iter) Uint8Value() uint8 {
-	return uint8(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(uint8(0))).Uint())
+	return uint8(funcs.ConvertValue(it.Value(), reflect.TypeOf(uint8(0))).Uint())
 }
 
 // NextUint8Value retrieves the next value as a uint8 for cases where you know the iterator has another value.
@@ -341,9 +579,9 @@ func (it *Iter) NextUint8Value() uint8 {
 
 // Uint16Value reads the value and converts it to a uint16.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a uint16.
+// Panics with a ConversionError if the value is not convertible to a uint16.
 func (it *Iter) Uint16Value() uint16 {
-	return uint16(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(uint16(0))).Uint())
+	return uint16(funcs.ConvertValue(it.Value(), reflect.TypeOf(uint16(0))).Uint())
 }
 
 // NextUint16Value retrieves the next value as a uint16 for cases where you know the iterator has another value.
@@ -355,9 +593,9 @@ func (it *Iter) NextUint16Value() uint16 {
 
 // Uint32Value reads the value and converts it to a uint32.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a uint32.
+// Panics with a ConversionError if the value is not convertible to a uint32.
 func (it *Iter) Uint32Value() uint32 {
-	return uint32(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(uint32(0))).Uint())
+	return uint32(funcs.ConvertValue(it.Value(), reflect.TypeOf(uint32(0))).Uint())
 }
 
 // NextUint32Value retrieves the next value as a uint32 for cases where you know the iterator has another value.
@@ -369,9 +607,9 @@ func (it *Iter) NextUint32Value() uint32 {
 
 // Uint64Value reads the value and converts it to a uint64.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a uint64.
+// Panics with a ConversionError if the value is not convertible to a uint64.
 func (it *Iter) Uint64Value() uint64 {
-	return reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(uint64(0))).Uint()
+	return funcs.ConvertValue(it.Value(), reflect.TypeOf(uint64(0))).Uint()
 }
 
 // NextUint64Value retrieves the next value as a uint64 for cases where you know the iterator has another value.
@@ -383,9 +621,9 @@ func (it *Iter) NextUint64Value() uint64 {
 
 // Float32Value reads the value and converts it to a float32.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a float32.
+// Panics with a ConversionError if the value is not convertible to a float32.
 func (it *Iter) Float32Value() float32 {
-	return float32(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(float32(0))).Float())
+	return float32(funcs.ConvertValue(it.Value(), reflect.TypeOf(float32(0))).Float())
 }
 
 // NextFloat32Value retrieves the next value as a float32 for cases where you know the iterator has another value.
@@ -397,9 +635,9 @@ func (it *Iter) NextFloat32Value() float32 {
 
 // Float64Value reads the value and converts it to a float64.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a float64.
+// Panics with a ConversionError if the value is not convertible to a float64.
 func (it *Iter) Float64Value() float64 {
-	return reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(float64(0))).Float()
+	return funcs.ConvertValue(it.Value(), reflect.TypeOf(float64(0))).Float()
 }
 
 // NextFloat64Value retrieves the next value as a float64 for cases where you know the iterator has another value.
@@ -411,9 +649,9 @@ func (it *Iter) NextFloat64Value() float64 {
 
 // Complex64Value reads the value and converts it to a complex64.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a complex64.
+// Panics with a ConversionError if the value is not convertible to a complex64.
 func (it *Iter) Complex64Value() complex64 {
-	return complex64(reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(complex64(0))).Complex())
+	return complex64(funcs.ConvertValue(it.Value(), reflect.TypeOf(complex64(0))).Complex())
 }
 
 // NextComplex64Value retrieves the next value as a complex64 for cases where you know the iterator has another value.
@@ -425,9 +663,9 @@ func (it *Iter) NextComplex64Value() complex64 {
 
 // Complex128Value reads the value and converts it to a complex128.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a complex128.
+// Panics with a ConversionError if the value is not convertible to a complex128.
 func (it *Iter) Complex128Value() complex128 {
-	return reflect.ValueOf(it.Value()).Convert(reflect.TypeOf(complex128(0))).Complex()
+	return funcs.ConvertValue(it.Value(), reflect.TypeOf(complex128(0))).Complex()
 }
 
 // NextComplex128Value retrieves the next value as a complex128 for cases where you know the iterator has another value.
@@ -439,9 +677,9 @@ func (it *Iter) NextComplex128Value() complex128 {
 
 // StringValue reads the value and converts it to a string.
 // Panics if Value() method panics.
-// Panics if the value is not convertible to a string.
+// Panics with a ConversionError if the value is not convertible to a string.
 func (it *Iter) StringValue() string {
-	return fmt.Sprintf("%s", reflect.ValueOf(it.Value()).Convert(reflect.TypeOf("")))
+	return fmt.Sprintf("%s", funcs.ConvertValue(it.Value(), reflect.TypeOf("")))
 }
 
 // NextStringValue retrieves the next value as a string for cases where you know the iterator has another value.
@@ -451,6 +689,72 @@ func (it *Iter) NextStringValue() string {
 	return it.StringValue()
 }
 
+// DurationValue reads the value and converts it to a time.Duration.
+// The value may be a time.Duration, an integer number of nanoseconds, or a string accepted by time.ParseDuration.
+// Panics if Value() method panics.
+// Panics with a ConversionError if the value is not convertible to a time.Duration.
+func (it *Iter) DurationValue() time.Duration {
+	value := it.Value()
+
+	switch val := value.(type) {
+	case time.Duration:
+		return val
+	case string:
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			panic(ConversionError{Value: value, TargetType: reflect.TypeOf(d), Cause: err.Error()})
+		}
+
+		return d
+	default:
+		if rv := reflect.ValueOf(val); rv.IsValid() && rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64 {
+			return time.Duration(rv.Int())
+		}
+	}
+
+	panic(ConversionError{Value: value, TargetType: reflect.TypeOf(time.Duration(0)), Cause: CauseIncompatibleType})
+}
+
+// NextDurationValue retrieves the next value as a time.Duration for cases where you know the iterator has another value.
+// Panics if Next() or DurationValue() panics.
+func (it *Iter) NextDurationValue() time.Duration {
+	it.Next()
+	return it.DurationValue()
+}
+
+// TimeValue reads the value and converts it to a time.Time.
+// The value may be a time.Time, an integer number of unix seconds, or an RFC3339 string.
+// Panics if Value() method panics.
+// Panics with a ConversionError if the value is not convertible to a time.Time.
+func (it *Iter) TimeValue() time.Time {
+	value := it.Value()
+
+	switch val := value.(type) {
+	case time.Time:
+		return val
+	case string:
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			panic(ConversionError{Value: value, TargetType: reflect.TypeOf(t), Cause: err.Error()})
+		}
+
+		return t
+	default:
+		if rv := reflect.ValueOf(val); rv.IsValid() && rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64 {
+			return time.Unix(rv.Int(), 0)
+		}
+	}
+
+	panic(ConversionError{Value: value, TargetType: reflect.TypeOf(time.Time{}), Cause: CauseIncompatibleType})
+}
+
+// NextTimeValue retrieves the next value as a time.Time for cases where you know the iterator has another value.
+// Panics if Next() or TimeValue() panics.
+func (it *Iter) NextTimeValue() time.Time {
+	it.Next()
+	return it.TimeValue()
+}
+
 // Unread places the given value at the end of an internal buffer of unread values.
 // It is up to the caller to unread correctly.
 // Example:
@@ -517,7 +821,7 @@ func (it *Iter) SplitIntoRows(cols uint) [][]interface{} {
 // Panics if the iter has already been exhausted.
 // Panics if cols = 0.
 // Panics is value is nil.
-// Panics if any value is not convertible to the type of the given value.
+// Panics with a ConversionError if any value is not convertible to the type of the given value.
 func (it *Iter) SplitIntoRowsOf(cols uint, value interface{}) interface{} {
 	if cols == 0 {
 		panic(ErrColsGreaterThanZero)
@@ -536,7 +840,7 @@ func (it *Iter) SplitIntoRowsOf(cols uint, value interface{}) interface{} {
 	)
 
 	for it.Next() {
-		row = reflect.Append(row, reflect.ValueOf(it.Value()).Convert(typ))
+		row = reflect.Append(row, funcs.ConvertValue(it.Value(), typ))
 		idx++
 
 		if idx == cols {
@@ -608,7 +912,7 @@ func (it *Iter) SplitIntoColumns(rows uint) [][]interface{} {
 // Panics if the iter has already been exhausted.
 // Panics if rows = 0.
 // Panics if value is nil.
-// Panics if any value is not convertible to the type of the given value.
+// Panics with a ConversionError if any value is not convertible to the type of the given value.
 func (it *Iter) SplitIntoColumnsOf(rows uint, value interface{}) interface{} {
 	if rows == 0 {
 		panic(ErrRowsGreaterThanZero)
@@ -650,7 +954,7 @@ func (it *Iter) SplitIntoColumnsOf(rows uint, value interface{}) interface{} {
 
 		row := reflect.MakeSlice(reflect.SliceOf(typ), end-start, end-start)
 		for j, colIdx := start, 0; j < end; j, colIdx = j+1, colIdx+1 {
-			row.Index(colIdx).Set(reflect.ValueOf(values[j]).Convert(typ))
+			row.Index(colIdx).Set(funcs.ConvertValue(values[j], typ))
 		}
 		split.Index(i).Set(row)
 
@@ -661,6 +965,323 @@ func (it *Iter) SplitIntoColumnsOf(rows uint, value interface{}) interface{} {
 	return split.Interface()
 }
 
+// All returns true if pred returns true for all remaining elements, or there are no remaining elements.
+// Stops reading at the first element that fails pred, so any elements after it can still be read.
+func (it *Iter) All(pred func(element interface{}) bool) bool {
+	for it.Next() {
+		if !pred(it.Value()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Any returns true if pred returns true for any remaining element, or false if there are no remaining elements.
+// Stops reading at the first element that passes pred, so any elements after it can still be read.
+func (it *Iter) Any(pred func(element interface{}) bool) bool {
+	for it.Next() {
+		if pred(it.Value()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply returns the result of applying the given transform to this Iter, which is just t(it).
+// This allows transforms to be chained fluently, EG Of(...).Apply(t1).Apply(t2) rather than t2(t1(Of(...))).
+func (it *Iter) Apply(t func(*Iter) *Iter) *Iter {
+	return t(it)
+}
+
+// AverageFloat64 returns the average of the remaining elements, each converted with Float64Value, and true.
+// If there are no more elements, returns (0, false).
+func (it *Iter) AverageFloat64() (float64, bool) {
+	var (
+		sum   float64
+		count int
+	)
+
+	for it.Next() {
+		sum += it.Float64Value()
+		count++
+	}
+
+	if count == 0 {
+		return 0, false
+	}
+
+	return sum / float64(count), true
+}
+
+// BatchByTime returns an Iter of []interface{} batches, where each batch contains the elements that arrived within a window of time.
+// A window starts when the first element of a batch arrives, and any element that arrives before the window has elapsed is added to the batch.
+// The first element that arrives after the window has elapsed completes the batch, and starts the next window.
+// Since elements are pulled from this Iter, a batch is not iterated until an element arrives after its window, or this Iter is exhausted.
+// Panics if window <= 0.
+func (it *Iter) BatchByTime(window time.Duration) *Iter {
+	if window <= 0 {
+		panic(ErrWindowGreaterThanZero)
+	}
+
+	var (
+		next     interface{}
+		nextTime time.Time
+		haveNext bool
+	)
+
+	return New(func() (interface{}, bool) {
+		// Start batch with element that completed the last batch, if any
+		if !haveNext {
+			if !it.Next() {
+				return nil, false
+			}
+
+			next, nextTime = it.Value(), now()
+		}
+
+		var (
+			batch       = []interface{}{next}
+			windowStart = nextTime
+		)
+
+		haveNext = false
+		for it.Next() {
+			val, arrived := it.Value(), now()
+			if arrived.Sub(windowStart) >= window {
+				next, nextTime, haveNext = val, arrived, true
+				break
+			}
+
+			batch = append(batch, val)
+		}
+
+		return batch, true
+	})
+}
+
+// Cycle returns an Iter that iterates the elements of this Iter, then repeats them indefinitely.
+// The elements are buffered as they are read on the first pass, so the memory cost is the size of the whole source.
+// If this Iter is empty, the result is an empty Iter rather than an infinite one.
+// The source should not be read directly once it is cycled.
+func (it *Iter) Cycle() *Iter {
+	var (
+		buffer     []interface{}
+		idx        int
+		sourceDone bool
+	)
+
+	return New(func() (interface{}, bool) {
+		// First pass reads from source, buffering each element
+		if !sourceDone {
+			if it.Next() {
+				val := it.Value()
+				buffer = append(buffer, val)
+				return val, true
+			}
+
+			sourceDone = true
+		}
+
+		// An empty source cannot be repeated
+		if len(buffer) == 0 {
+			return nil, false
+		}
+
+		// Repeat buffer
+		val := buffer[idx]
+		idx = (idx + 1) % len(buffer)
+		return val, true
+	})
+}
+
+// EachUntil calls f for each remaining element, until f returns a non-nil error.
+// Returns the error returned by f, or nil if all elements were passed to f without error.
+// The iterator is left positioned after the last element passed to f, so that any remaining elements can still be read.
+func (it *Iter) EachUntil(f func(element interface{}) error) error {
+	for it.Next() {
+		if err := f(it.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FlattenElements returns an Iter that lazily flattens elements that are arrays or slices into their elements.
+// Elements that are not arrays or slices are iterated as is.
+// Only one level is flattened, so an element that is a two dimensional slice contributes a slice for each row.
+// Each array or slice element is read only when the prior one has been fully iterated, so an infinite source can be flattened.
+func (it *Iter) FlattenElements() *Iter {
+	var (
+		arraySlice reflect.Value
+		n          int
+		sz         int
+	)
+
+	return New(func() (interface{}, bool) {
+		// Search for next non-empty array or slice, or next scalar element, if we need to
+		for n == sz {
+			if !it.Next() {
+				return nil, false
+			}
+
+			value := it.Value()
+			arraySlice = reflect.ValueOf(value)
+			if kind := arraySlice.Kind(); !((kind == reflect.Array) || (kind == reflect.Slice)) {
+				return value, true
+			}
+
+			n = 0
+			sz = arraySlice.Len()
+		}
+
+		value := arraySlice.Index(n).Interface()
+		n++
+		return value, true
+	})
+}
+
+// Intersperse returns an Iter that iterates the elements of this Iter with sep between each pair of elements,
+// EG, Of(1, 2, 3).Intersperse(0) iterates 1, 0, 2, 0, 3.
+// There is no sep before the first element or after the last element, so an empty Iter or an Iter of one element is iterated as is.
+func (it *Iter) Intersperse(sep interface{}) *Iter {
+	var (
+		started  bool
+		next     interface{}
+		haveNext bool
+	)
+
+	return New(func() (interface{}, bool) {
+		// Return element read after last sep
+		if haveNext {
+			haveNext = false
+			return next, true
+		}
+
+		if !it.Next() {
+			return nil, false
+		}
+
+		// First element is not preceded by a sep
+		val := it.Value()
+		if !started {
+			started = true
+			return val, true
+		}
+
+		next, haveNext = val, true
+		return sep, true
+	})
+}
+
+// Max returns the maximum element according to the provided comparator, and true.
+// If there are no more elements, returns (nil, false).
+// If more than one element is the maximum, the first such element is returned.
+func (it *Iter) Max(less func(element1, element2 interface{}) bool) (interface{}, bool) {
+	if !it.Next() {
+		return nil, false
+	}
+
+	max := it.Value()
+	for it.Next() {
+		if element := it.Value(); less(max, element) {
+			max = element
+		}
+	}
+
+	return max, true
+}
+
+// Min returns the minimum element according to the provided comparator, and true.
+// If there are no more elements, returns (nil, false).
+// If more than one element is the minimum, the first such element is returned.
+func (it *Iter) Min(less func(element1, element2 interface{}) bool) (interface{}, bool) {
+	if !it.Next() {
+		return nil, false
+	}
+
+	min := it.Value()
+	for it.Next() {
+		if element := it.Value(); less(element, min) {
+			min = element
+		}
+	}
+
+	return min, true
+}
+
+// Partition splits the iterator into two Iters: the first iterates the elements that pass the predicate, the second iterates the elements that do not.
+// The source is read lazily, and each element is read from the source exactly once, regardless of which Iter reads it.
+// When one Iter reads an element from the source that belongs to the other Iter, the element is buffered until the other Iter reads it.
+// If only one Iter is read, or one is read far more than the other, the buffer of the other Iter may grow to hold every element it has not yet read.
+// The source should not be read directly once it is partitioned.
+func (it *Iter) Partition(pred func(element interface{}) bool) (*Iter, *Iter) {
+	var (
+		matched   []interface{}
+		unmatched []interface{}
+	)
+
+	// Returns an iterating function that reads from its own buffer first, then from the source,
+	// buffering elements for the other partition until an element is found for this partition
+	partitionIterFunc := func(wantMatch bool, own, other *[]interface{}) func() (interface{}, bool) {
+		return func() (interface{}, bool) {
+			if len(*own) > 0 {
+				val := (*own)[0]
+				*own = (*own)[1:]
+				return val, true
+			}
+
+			for it.Next() {
+				val := it.Value()
+				if pred(val) == wantMatch {
+					return val, true
+				}
+
+				*other = append(*other, val)
+			}
+
+			return nil, false
+		}
+	}
+
+	return New(partitionIterFunc(true, &matched, &unmatched)), New(partitionIterFunc(false, &unmatched, &matched))
+}
+
+// Scan returns an Iter that lazily iterates the running accumulation of the elements of this Iter.
+// For each element, the accumulator is set to f(accumulator, element) and iterated, where the initial accumulator is identity.
+// EG, a running sum of 1, 2, 3 with an identity of 0 iterates 1, 3, 6.
+// Since it is lazy, Scan can be used on an infinite Iter, as long as the caller stops reading at some point.
+func (it *Iter) Scan(identity interface{}, f func(accumulator, element interface{}) interface{}) *Iter {
+	accumulator := identity
+
+	return New(func() (interface{}, bool) {
+		if !it.Next() {
+			return nil, false
+		}
+
+		accumulator = f(accumulator, it.Value())
+		return accumulator, true
+	})
+}
+
+// SumFloat64 returns the sum of the remaining elements, each converted with Float64Value, and true.
+// If there are no more elements, returns (0, false).
+func (it *Iter) SumFloat64() (float64, bool) {
+	var (
+		sum    float64
+		hasSum bool
+	)
+
+	for it.Next() {
+		sum += it.Float64Value()
+		hasSum = true
+	}
+
+	return sum, hasSum
+}
+
 // ReaderFunc is an adapter to allow the use of ordinary functions as Readers.
 // If f is a function with the appropriate signature, ReaderFunc(f) is a Reader that calls f.
 type ReaderFunc func(p []byte) (n int, err error)
@@ -696,6 +1317,18 @@ func (it *Iter) ToReader() io.Reader {
 	})
 }
 
+// ToSet collects the distinct elements into a set.
+// Panics if any element is not comparable, since it cannot be a map key.
+func (it *Iter) ToSet() map[interface{}]struct{} {
+	set := map[interface{}]struct{}{}
+
+	for it.Next() {
+		set[it.Value()] = struct{}{}
+	}
+
+	return set
+}
+
 // ToSlice collects the elements into a slice
 func (it *Iter) ToSlice() []interface{} {
 	slice := []interface{}{}
@@ -707,10 +1340,23 @@ func (it *Iter) ToSlice() []interface{} {
 	return slice
 }
 
+// ToSliceN collects at most n elements into a slice.
+// Unlike ToSlice, the iterator is not exhausted, so that Next can be called afterwards to continue reading the remaining elements.
+// If n <= 0, or the iter has no more elements, an allocated empty slice is returned.
+func (it *Iter) ToSliceN(n int) []interface{} {
+	slice := []interface{}{}
+
+	for i := 0; (i < n) && it.Next(); i++ {
+		slice = append(slice, it.Value())
+	}
+
+	return slice
+}
+
 // ToSliceOf returns a slice of all elements, where the slice type is the same as the type of the given value.
 // EG, if a value of type int is passed, a []int is returned.
 // Panics if value is nil.
-// Panics if any value is not convertible to the type of the given value.
+// Panics with a ConversionError if any value is not convertible to the type of the given value.
 func (it *Iter) ToSliceOf(value interface{}) interface{} {
 	if value == nil {
 		panic(ErrValueCannotBeNil)
@@ -722,8 +1368,21 @@ func (it *Iter) ToSliceOf(value interface{}) interface{} {
 	)
 
 	for it.Next() {
-		slice = reflect.Append(slice, reflect.ValueOf(it.Value()).Convert(typ))
+		slice = reflect.Append(slice, funcs.ConvertValue(it.Value(), typ))
 	}
 
 	return slice.Interface()
 }
+
+// ToStringSlice collects the elements into a []string.
+// Each element is converted using StringValue, which avoids the overhead of building the slice via reflection as ToSliceOf does.
+// Panics if any value is not convertible to a string.
+func (it *Iter) ToStringSlice() []string {
+	slice := []string{}
+
+	for it.Next() {
+		slice = append(slice, it.StringValue())
+	}
+
+	return slice
+}
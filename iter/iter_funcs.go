@@ -2,8 +2,13 @@ package iter
 
 import (
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -12,12 +17,19 @@ const (
 	ErrArraySliceIterFuncArg = "ArraySliceIterFunc argument must be an array or slice"
 	ErrInvalidUTF8Encoding   = "Invalid UTF 8 encoding"
 	ErrMapIterFuncArg        = "MapIterFunc argument must be a map"
+	ErrChannelIterFuncArg    = "ChannelIterFunc argument must be a channel that can be received from"
 )
 
 // ArraySliceIterFunc iterates an array or slice outermost dimension.
 // EG, if an [][]int is passed, the iterator returns []int values.
 // Panics if the value is not an array or slice.
 func ArraySliceIterFunc(arraySlice reflect.Value) func() (interface{}, bool) {
+	iterFunc, _ := arraySliceIterFunc(arraySlice)
+	return iterFunc
+}
+
+// arraySliceIterFunc is ArraySliceIterFunc, that also returns a function that returns the number of values not yet iterated.
+func arraySliceIterFunc(arraySlice reflect.Value) (func() (interface{}, bool), func() int) {
 	if (arraySlice.Kind() != reflect.Array) && (arraySlice.Kind() != reflect.Slice) {
 		panic(ErrArraySliceIterFuncArg)
 	}
@@ -27,7 +39,7 @@ func ArraySliceIterFunc(arraySlice reflect.Value) func() (interface{}, bool) {
 		idx int
 	)
 
-	return func() (interface{}, bool) {
+	iterFunc := func() (interface{}, bool) {
 		if idx == num {
 			// Exhausted all values - don't care how many calls are made once exhausted
 			return nil, false
@@ -38,6 +50,8 @@ func ArraySliceIterFunc(arraySlice reflect.Value) func() (interface{}, bool) {
 		idx++
 		return val, true
 	}
+
+	return iterFunc, func() int { return num - idx }
 }
 
 // KeyValue contains a key value pair from a map
@@ -79,6 +93,41 @@ func MapIterFunc(aMap reflect.Value) func() (interface{}, bool) {
 	}
 }
 
+// SortedMapIterFunc iterates a map in ascending key order according to keyLess, where next item is a KeyValue instance.
+// Unlike MapIterFunc, the order of iteration is the same every time the same map is iterated.
+// The keys and values are copied and sorted when SortedMapIterFunc is called, later changes to the map are not reflected in the iteration.
+// Panics if the value is not a map.
+func SortedMapIterFunc(aMap reflect.Value, keyLess func(key1, key2 interface{}) bool) func() (interface{}, bool) {
+	if aMap.Kind() != reflect.Map {
+		panic(ErrMapIterFuncArg)
+	}
+
+	var (
+		keys    = aMap.MapKeys()
+		entries = make([]KeyValue, len(keys))
+		idx     int
+	)
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keyLess(keys[i].Interface(), keys[j].Interface())
+	})
+
+	for i, key := range keys {
+		entries[i] = KeyValue{Key: key.Interface(), Value: aMap.MapIndex(key).Interface()}
+	}
+
+	return func() (interface{}, bool) {
+		if idx == len(entries) {
+			// Exhausted all entries - don't care how many calls are made once exhausted
+			return nil, false
+		}
+
+		entry := entries[idx]
+		idx++
+		return entry, true
+	}
+}
+
 // NoValueIterFunc always returns (nil, false)
 func NoValueIterFunc() (interface{}, bool) {
 	return nil, false
@@ -229,6 +278,133 @@ func ReaderToLinesIterFunc(src io.Reader) func() (interface{}, bool) {
 	}
 }
 
+// ReaderToWordsIterFunc iterates the bytes of an io.Reader, and interprets them as runes.
+// Runes are read until a separator rune or EOF occurs, where a separator is any rune for which isSep returns true.
+// If isSep is not provided, it defaults to unicode.IsSpace.
+// For each word contained in the Reader, returns (string, true), where the string is non-empty and does not contain any separators.
+// Consecutive separators are treated as a single separator, so no empty words are returned.
+// After the last word has been returned, all further calls return ("", false).
+// When any other error occurs (including invalid UTF-8 encoding), panics with the error.
+func ReaderToWordsIterFunc(src io.Reader, isSep ...func(rune) bool) func() (interface{}, bool) {
+	// Use ReaderToRunesIterFunc to read individual runes until a word is read
+	var (
+		runesIter = ReaderToRunesIterFunc(src)
+		sepFn     = unicode.IsSpace
+		str       strings.Builder
+	)
+
+	if len(isSep) > 0 {
+		sepFn = isSep[0]
+	}
+
+	return func() (interface{}, bool) {
+		str.Reset()
+
+		for {
+			codePoint, haveIt := runesIter()
+
+			if !haveIt {
+				if str.Len() > 0 {
+					return str.String(), true
+				}
+
+				return "", false
+			}
+
+			if sepFn(codePoint.(rune)) {
+				if str.Len() > 0 {
+					return str.String(), true
+				}
+
+				continue
+			}
+
+			str.WriteRune(codePoint.(rune))
+		}
+	}
+}
+
+// ChannelIterFunc iterates the values received from a channel.
+// For each value received, returns (value, true).
+// When the channel is closed, returns (nil, false).
+// Each call blocks until a value is received or the channel is closed.
+// Panics if the value is not a channel that can be received from.
+func ChannelIterFunc(ch reflect.Value) func() (interface{}, bool) {
+	if (ch.Kind() != reflect.Chan) || (ch.Type().ChanDir()&reflect.RecvDir == 0) {
+		panic(ErrChannelIterFuncArg)
+	}
+
+	done := false
+
+	return func() (interface{}, bool) {
+		if done {
+			return nil, false
+		}
+
+		val, haveIt := ch.Recv()
+		if !haveIt {
+			// Channel has been closed
+			done = true
+			return nil, false
+		}
+
+		return val.Interface(), true
+	}
+}
+
+// dirWalkEntry is a path to be visited by DirWalkIterFunc, and its FileInfo if it is already known
+type dirWalkEntry struct {
+	path string
+	info os.FileInfo
+}
+
+// DirWalkIterFunc iterates the paths of the files under a root directory, recursively, in the same manner as filepath.Walk.
+// Files are visited in lexical order, and paths begin with root.
+// Directories themselves are not iterated, and symbolic links are iterated as files without being followed,
+// as each path is examined with os.Lstat.
+// If root is not a directory, the only path iterated is root.
+// Each directory is read when the walk reaches it, so the tree is not read any further than the caller iterates.
+// For each file, returns (path, true).
+// When all files have been iterated, returns (nil, false).
+// When any error occurs reading the tree, panics with the error.
+func DirWalkIterFunc(root string) func() (interface{}, bool) {
+	// Entries remaining to be visited, in reverse order
+	pending := []dirWalkEntry{{path: root}}
+
+	return func() (interface{}, bool) {
+		for len(pending) > 0 {
+			next := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+
+			if next.info == nil {
+				// Only root has not already been examined by ReadDir
+				info, err := os.Lstat(next.path)
+				if err != nil {
+					panic(err)
+				}
+
+				next.info = info
+			}
+
+			if !next.info.IsDir() {
+				return next.path, true
+			}
+
+			// Entries are sorted by name and examined with Lstat, push them in reverse order so that the first name is visited first
+			entries, err := ioutil.ReadDir(next.path)
+			if err != nil {
+				panic(err)
+			}
+
+			for i := len(entries) - 1; i >= 0; i-- {
+				pending = append(pending, dirWalkEntry{path: filepath.Join(next.path, entries[i].Name()), info: entries[i]})
+			}
+		}
+
+		return nil, false
+	}
+}
+
 // FlattenArraySlice flattens an array or slice of any number of dimensions into a new slice of one dimension.
 // EG, an [][]int{{1, 2}, {3, 4, 5}} is flattened into an []interface{}{1,2,3,4,5}.
 // Note that in case where the element type is interface{}, a mixture of values and arrays/slices could be used.
@@ -3,10 +3,14 @@
 package iter
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -176,6 +180,57 @@ func TestMapIterFunc(t *testing.T) {
 	}()
 }
 
+func TestSortedMapIterFunc(t *testing.T) {
+	var (
+		m       = map[int]string{3: "c", 1: "a", 4: "d", 2: "b"}
+		keyLess = func(key1, key2 interface{}) bool { return key1.(int) < key2.(int) }
+		sorted  = []KeyValue{{Key: 1, Value: "a"}, {Key: 2, Value: "b"}, {Key: 3, Value: "c"}, {Key: 4, Value: "d"}}
+	)
+
+	// Two iterations of the same map produce identical sequences
+	for i := 0; i < 2; i++ {
+		iterFunc := SortedMapIterFunc(reflect.ValueOf(m), keyLess)
+		for _, kv := range sorted {
+			val, haveIt := iterFunc()
+			assert.Equal(t, kv, val)
+			assert.True(t, haveIt)
+		}
+
+		val, haveIt := iterFunc()
+		assert.Nil(t, val)
+		assert.False(t, haveIt)
+
+		// Further calls once exhausted
+		val, haveIt = iterFunc()
+		assert.Nil(t, val)
+		assert.False(t, haveIt)
+	}
+
+	// Later changes to the map are not reflected, including deleted keys
+	var (
+		changed  = map[int]string{1: "a", 2: "b"}
+		iterFunc = SortedMapIterFunc(reflect.ValueOf(changed), keyLess)
+	)
+	changed[1] = "x"
+	delete(changed, 2)
+	changed[3] = "c"
+	assert.Equal(t, []interface{}{KeyValue{Key: 1, Value: "a"}, KeyValue{Key: 2, Value: "b"}}, New(iterFunc).ToSlice())
+
+	// Empty map
+	val, haveIt := SortedMapIterFunc(reflect.ValueOf(map[int]string{}), keyLess)()
+	assert.Nil(t, val)
+	assert.False(t, haveIt)
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrMapIterFuncArg, recover())
+		}()
+
+		SortedMapIterFunc(reflect.ValueOf(1), keyLess)
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestNoValueIterFunc(t *testing.T) {
 	iterFunc := NoValueIterFunc
 
@@ -431,6 +486,145 @@ func TestReaderToLinesIterFuncAndOfReaderLines(t *testing.T) {
 	}
 }
 
+func TestReaderToWordsIterFuncAndOfReaderWords(t *testing.T) {
+	// Default separator
+	for input, words := range map[string][]string{
+		"":                      {},
+		" \t\n":                 {},
+		"one":                   {"one"},
+		"  one two\tthree\r\n ": {"one", "two", "three"},
+		"a,b c":                 {"a,b", "c"},
+	} {
+		var (
+			iterFunc = ReaderToWordsIterFunc(strings.NewReader(input))
+			iter     = OfReaderWords(strings.NewReader(input))
+			val      interface{}
+			next     bool
+		)
+
+		for _, word := range words {
+			val, next = iterFunc()
+			assert.Equal(t, word, val)
+			assert.True(t, next)
+		}
+
+		val, next = iterFunc()
+		assert.Equal(t, "", val)
+		assert.False(t, next)
+
+		assert.Equal(t, words, iter.ToStringSlice())
+	}
+
+	// Custom separator treating commas as separators
+	isSep := func(r rune) bool { return (r == ',') || unicode.IsSpace(r) }
+	assert.Equal(t, []string{"a", "b", "c", "d"}, OfReaderWords(strings.NewReader("a,b, c,,d,"), isSep).ToStringSlice())
+}
+
+func TestChannelIterFuncAndOfChannel(t *testing.T) {
+	// Closed empty channel
+	ch := make(chan int)
+	close(ch)
+
+	iterFunc := ChannelIterFunc(reflect.ValueOf(ch))
+	_, next := iterFunc()
+	assert.False(t, next)
+
+	_, next = iterFunc()
+	assert.False(t, next)
+
+	// Buffered channel of two values
+	ch = make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	assert.Equal(t, []interface{}{1, 2}, OfChannel(ch).ToSlice())
+
+	// Receive only channel fed by a goroutine
+	ch = make(chan int)
+	go func() {
+		defer close(ch)
+
+		for i := 1; i <= 3; i++ {
+			ch <- i
+		}
+	}()
+	assert.Equal(t, []interface{}{1, 2, 3}, OfChannel((<-chan int)(ch)).ToSlice())
+
+	// Not a channel
+	func() {
+		defer func() {
+			assert.Equal(t, ErrChannelIterFuncArg, recover())
+		}()
+
+		ChannelIterFunc(reflect.ValueOf(1))
+		assert.Fail(t, "Must panic on non-channel")
+	}()
+
+	// Send only channel
+	func() {
+		defer func() {
+			assert.Equal(t, ErrChannelIterFuncArg, recover())
+		}()
+
+		ChannelIterFunc(reflect.ValueOf((chan<- int)(make(chan int))))
+		assert.Fail(t, "Must panic on send only channel")
+	}()
+}
+
+func TestDirWalkIterFuncAndOfDirWalk(t *testing.T) {
+	root, err := ioutil.TempDir("", "dirwalk")
+	assert.Nil(t, err)
+	defer os.RemoveAll(root)
+
+	// Tree of files and directories, including an empty directory
+	var (
+		path  = func(elems ...string) string { return filepath.Join(append([]string{root}, elems...)...) }
+		files = []string{
+			path("a.txt"),
+			path("b", "c.txt"),
+			path("b", "d", "e.txt"),
+			path("b", "f.txt"),
+			path("g.txt"),
+		}
+	)
+
+	assert.Nil(t, os.MkdirAll(path("b", "d"), 0755))
+	assert.Nil(t, os.MkdirAll(path("h"), 0755))
+	for _, file := range files {
+		assert.Nil(t, ioutil.WriteFile(file, []byte(file), 0644))
+	}
+
+	// All files are iterated in lexical order
+	iter := New(DirWalkIterFunc(root))
+	for _, file := range files {
+		assert.True(t, iter.Next())
+		assert.Equal(t, file, iter.Value())
+	}
+	assert.False(t, iter.Next())
+
+	assert.Equal(t, []interface{}{path("b", "c.txt"), path("b", "d", "e.txt"), path("b", "f.txt")}, OfDirWalk(path("b")).ToSlice())
+
+	// Root that is a file
+	assert.Equal(t, []interface{}{path("a.txt")}, OfDirWalk(path("a.txt")).ToSlice())
+
+	// Empty directory
+	assert.Equal(t, []interface{}{}, OfDirWalk(path("h")).ToSlice())
+
+	// Symbolic link to a directory is iterated as a file, without being followed
+	assert.Nil(t, os.Symlink(path("b"), path("h", "link")))
+	assert.Equal(t, []interface{}{path("h", "link")}, OfDirWalk(path("h")).ToSlice())
+
+	// Error
+	func() {
+		defer func() {
+			assert.True(t, os.IsNotExist(recover().(error)))
+		}()
+
+		OfDirWalk(path("missing")).Next()
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestFlattenArraySlice(t *testing.T) {
 	f := FlattenArraySlice([2]int{1, 2})
 	assert.Equal(t, []interface{}{1, 2}, f)
@@ -3,10 +3,14 @@
 package iter
 
 import (
+	"fmt"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/bantling/gomicro/funcs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -125,6 +129,202 @@ func TestOfElements(t *testing.T) {
 	assert.False(t, iter.Next())
 }
 
+func TestOfMapSorted(t *testing.T) {
+	var (
+		m      = map[string]int{"c": 3, "a": 1, "d": 4, "b": 2}
+		sorted = []interface{}{
+			KeyValue{Key: "a", Value: 1},
+			KeyValue{Key: "b", Value: 2},
+			KeyValue{Key: "c", Value: 3},
+			KeyValue{Key: "d", Value: 4},
+		}
+	)
+
+	// Same map iterated twice yields the same sequence
+	assert.Equal(t, sorted, OfMapSorted(m, funcs.StringSortFunc).ToSlice())
+	assert.Equal(t, sorted, OfMapSorted(m, funcs.StringSortFunc).ToSlice())
+
+	// Order is determined by the comparator
+	assert.Equal(
+		t,
+		[]interface{}{KeyValue{Key: 2, Value: "b"}, KeyValue{Key: 1, Value: "a"}},
+		OfMapSorted(map[int]string{1: "a", 2: "b"}, func(k1, k2 interface{}) bool { return k1.(int) > k2.(int) }).ToSlice(),
+	)
+
+	assert.Equal(t, []interface{}{}, OfMapSorted(map[int]int{}, funcs.IntSortFunc).ToSlice())
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrMapIterFuncArg, recover())
+		}()
+
+		OfMapSorted([]int{1}, funcs.IntSortFunc)
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestOfReaderErr(t *testing.T) {
+	// No error
+	iter, errFn := OfReaderErr(strings.NewReader("ab"))
+	assert.Nil(t, errFn())
+	assert.Equal(t, []interface{}{byte('a'), byte('b')}, iter.ToSlice())
+	assert.Nil(t, errFn())
+
+	// Error after a few bytes, where the last byte is returned with the error
+	var (
+		readErr = fmt.Errorf("connection reset")
+		data    = []byte("abc")
+		src     = ReaderFunc(func(p []byte) (int, error) {
+			if len(data) == 0 {
+				return 0, readErr
+			}
+
+			p[0] = data[0]
+			data = data[1:]
+			if len(data) == 0 {
+				return 1, readErr
+			}
+
+			return 1, nil
+		})
+	)
+
+	iter, errFn = OfReaderErr(src)
+	assert.Equal(t, []interface{}{byte('a'), byte('b'), byte('c')}, iter.ToSlice())
+	assert.Equal(t, readErr, errFn())
+}
+
+func TestOfChannelWithDone(t *testing.T) {
+	// Infinite producer that stops when done is closed
+	var (
+		ch     = make(chan int)
+		done   = make(chan struct{})
+		exited = make(chan struct{})
+	)
+
+	go func() {
+		defer close(exited)
+
+		for i := 1; ; i++ {
+			select {
+			case ch <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// Consumer only wants 2 elements
+	iter, stop := OfChannelWithDone(ch, done)
+	assert.Equal(t, 1, iter.NextValue())
+	assert.Equal(t, 2, iter.NextValue())
+	stop()
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		assert.Fail(t, "Producer did not exit")
+	}
+
+	// Iter is exhausted once stopped, and stop can be called again
+	assert.False(t, iter.Next())
+	stop()
+
+	// Closing the channel exhausts the iter and closes done
+	ch = make(chan int, 2)
+	done = make(chan struct{})
+	ch <- 1
+	ch <- 2
+	close(ch)
+
+	iter, _ = OfChannelWithDone(ch, done)
+	assert.Equal(t, []interface{}{1, 2}, iter.ToSlice())
+
+	select {
+	case <-done:
+	default:
+		assert.Fail(t, "done must be closed")
+	}
+
+	// Not a channel
+	func() {
+		defer func() {
+			assert.Equal(t, ErrChannelIterFuncArg, recover())
+		}()
+
+		OfChannelWithDone(1, make(chan struct{}))
+		assert.Fail(t, "Must panic on non-channel")
+	}()
+}
+
+func TestOfSeq(t *testing.T) {
+	var (
+		returned bool
+		seq      = func(yield func(interface{}) bool) {
+			defer func() { returned = true }()
+
+			for _, v := range []int{1, 2, 3} {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	)
+
+	// Fully iterated
+	iter, stop := OfSeq(seq)
+	assert.Equal(t, []interface{}{1, 2, 3}, iter.ToSlice())
+	assert.True(t, returned)
+	stop()
+
+	// Stopped early: seq returns before stop returns, so the goroutine is not leaked
+	returned = false
+	iter, stop = OfSeq(seq)
+	assert.Equal(t, 1, iter.NextValue())
+	assert.False(t, returned)
+	stop()
+	assert.True(t, returned)
+	assert.False(t, iter.Next())
+	stop()
+
+	// Stopped before reading: seq is never run
+	returned = false
+	iter, stop = OfSeq(seq)
+	stop()
+	assert.False(t, iter.Next())
+	assert.False(t, returned)
+
+	// A seq that ignores yield returning false is not sent any further values
+	var count int
+	iter, stop = OfSeq(func(yield func(interface{}) bool) {
+		for i := 0; i < 5; i++ {
+			yield(i)
+			count++
+		}
+	})
+	assert.Equal(t, 0, iter.NextValue())
+	stop()
+	assert.Equal(t, 5, count)
+	assert.False(t, iter.Next())
+
+	// Panics in seq are passed on to the consumer
+	iter, stop = OfSeq(func(yield func(interface{}) bool) {
+		yield(1)
+		panic("boom")
+	})
+	defer stop()
+	assert.Equal(t, 1, iter.NextValue())
+
+	func() {
+		defer func() {
+			assert.Equal(t, "boom", recover())
+		}()
+
+		iter.Next()
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestConcat(t *testing.T) {
 	iter := Concat()
 	assert.Equal(t, []interface{}{}, iter.ToSlice())
@@ -165,6 +365,37 @@ func TestConcat(t *testing.T) {
 	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 6}, iter.ToSlice())
 }
 
+func TestLen(t *testing.T) {
+	// Known length
+	iter := Of(1, 2, 3)
+	assert.Equal(t, 3, iter.Len())
+	assert.True(t, iter.Next())
+	assert.Equal(t, 3, iter.Len())
+	assert.Equal(t, 1, iter.Value())
+	assert.Equal(t, 2, iter.Len())
+
+	// Unread values are counted
+	iter.Unread(1)
+	assert.Equal(t, 3, iter.Len())
+	assert.Equal(t, []interface{}{1, 2, 3}, iter.ToSlice())
+	assert.Equal(t, 0, iter.Len())
+
+	assert.Equal(t, 0, Of().Len())
+	assert.Equal(t, 3, OfFlatten([][]int{{1}, {2, 3}}).Len())
+	assert.Equal(t, 2, OfElements([2]string{"a", "b"}).Len())
+
+	// Unknown length
+	assert.Equal(t, -1, OfElements(map[int]int{1: 1}).Len())
+	assert.Equal(t, -1, OfElements(1).Len())
+	assert.Equal(t, -1, OfReader(strings.NewReader("a")).Len())
+	assert.Equal(t, -1, Of(1, 2).Intersperse(0).Len())
+
+	// Exhausted Iter of unknown length
+	iter = OfReader(strings.NewReader(""))
+	assert.False(t, iter.Next())
+	assert.Equal(t, 0, iter.Len())
+}
+
 func TestValueOfType(t *testing.T) {
 	var (
 		v1   = "1"
@@ -180,6 +411,63 @@ func TestValueOfType(t *testing.T) {
 	assert.Equal(t, v2, v)
 }
 
+func TestConversionError(t *testing.T) {
+	// Incompatible type
+	func() {
+		defer func() {
+			err, isa := recover().(ConversionError)
+			assert.True(t, isa)
+			assert.Equal(t, "a", err.Value)
+			assert.Equal(t, reflect.TypeOf(0), err.TargetType)
+			assert.Equal(t, CauseIncompatibleType, err.Cause)
+			assert.Equal(t, "cannot convert a of type string to int: incompatible type", err.Error())
+		}()
+
+		Of("a").NextIntValue()
+		assert.Fail(t, "Must panic")
+	}()
+
+	// Nil value
+	func() {
+		defer func() {
+			assert.Equal(t, ConversionError{TargetType: reflect.TypeOf(""), Cause: CauseNilValue}, recover())
+		}()
+
+		Of(nil).NextStringValue()
+		assert.Fail(t, "Must panic")
+	}()
+
+	// Slice conversion
+	func() {
+		defer func() {
+			assert.Equal(t, ConversionError{Value: "b", TargetType: reflect.TypeOf(0.0), Cause: CauseIncompatibleType}, recover())
+		}()
+
+		Of(1, "b").ToSliceOf(0.0)
+		assert.Fail(t, "Must panic")
+	}()
+
+	// Slice too short to convert to an array
+	func() {
+		defer func() {
+			assert.Equal(t, ConversionError{Value: []int{1}, TargetType: reflect.TypeOf([2]int{}), Cause: CauseIncompatibleType}, recover())
+		}()
+
+		Of([]int{1}).NextValueOfType([2]int{})
+		assert.Fail(t, "Must panic")
+	}()
+
+	// Non-conversion panics are unchanged
+	func() {
+		defer func() {
+			assert.Equal(t, ErrValueNextFirst, recover())
+		}()
+
+		Of(1).IntValue()
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestBoolValue(t *testing.T) {
 	var (
 		iter = Of(true, false)
@@ -438,6 +726,79 @@ func TestStringValue(t *testing.T) {
 	assert.Equal(t, v2, v)
 }
 
+func TestDurationValue(t *testing.T) {
+	iter := Of(int64(1500), "1m30s", 2*time.Second)
+
+	assert.True(t, iter.Next())
+	var v time.Duration = iter.DurationValue()
+	assert.Equal(t, 1500*time.Nanosecond, v)
+	assert.Equal(t, 90*time.Second, iter.NextDurationValue())
+	assert.Equal(t, 2*time.Second, iter.NextDurationValue())
+
+	func() {
+		defer func() {
+			err := recover().(ConversionError)
+			assert.Equal(t, "1x", err.Value)
+			assert.Equal(t, reflect.TypeOf(time.Duration(0)), err.TargetType)
+			assert.Equal(t, `time: unknown unit "x" in duration "1x"`, err.Cause)
+		}()
+
+		Of("1x").NextDurationValue()
+		assert.Fail(t, "Must panic")
+	}()
+
+	func() {
+		defer func() {
+			assert.Equal(
+				t,
+				ConversionError{Value: 1.5, TargetType: reflect.TypeOf(time.Duration(0)), Cause: CauseIncompatibleType},
+				recover(),
+			)
+		}()
+
+		Of(1.5).NextDurationValue()
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestTimeValue(t *testing.T) {
+	var (
+		now  = time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		iter = Of(now.Unix(), "2021-03-04T05:06:07Z", now)
+	)
+
+	assert.True(t, iter.Next())
+	var v time.Time = iter.TimeValue()
+	assert.True(t, now.Equal(v))
+	assert.True(t, now.Equal(iter.NextTimeValue()))
+	assert.Equal(t, now, iter.NextTimeValue())
+
+	func() {
+		defer func() {
+			err := recover().(ConversionError)
+			assert.Equal(t, "2021-03-04", err.Value)
+			assert.Equal(t, reflect.TypeOf(time.Time{}), err.TargetType)
+			assert.Contains(t, err.Cause, "cannot parse")
+		}()
+
+		Of("2021-03-04").NextTimeValue()
+		assert.Fail(t, "Must panic")
+	}()
+
+	func() {
+		defer func() {
+			assert.Equal(
+				t,
+				ConversionError{Value: true, TargetType: reflect.TypeOf(time.Time{}), Cause: CauseIncompatibleType},
+				recover(),
+			)
+		}()
+
+		Of(true).NextTimeValue()
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestUnread(t *testing.T) {
 	iter := Of(1, 2, 3)
 	iter.Next()
@@ -877,6 +1238,262 @@ func TestSplitIntoColumnsOf(t *testing.T) {
 	}()
 }
 
+func TestAllAny(t *testing.T) {
+	isPositive := func(element interface{}) bool { return element.(int) > 0 }
+
+	// All
+	assert.True(t, Of().All(isPositive))
+	assert.True(t, Of(1, 2).All(isPositive))
+
+	iter := Of(1, -2, 3, 4)
+	assert.False(t, iter.All(isPositive))
+	assert.Equal(t, []interface{}{3, 4}, iter.ToSlice())
+
+	// Any
+	assert.False(t, Of().Any(isPositive))
+	assert.False(t, Of(-1, -2).Any(isPositive))
+
+	iter = Of(-1, 2, -3, 4)
+	assert.True(t, iter.Any(isPositive))
+	assert.Equal(t, []interface{}{-3, 4}, iter.ToSlice())
+}
+
+func TestApply(t *testing.T) {
+	// A transform that flattens slices into their elements
+	flatten := func(it *Iter) *Iter {
+		var elements *Iter
+
+		return New(func() (interface{}, bool) {
+			for (elements == nil) || !elements.Next() {
+				if !it.Next() {
+					return nil, false
+				}
+
+				elements = Of(it.Value().([]interface{})...)
+			}
+
+			return elements.Value(), true
+		})
+	}
+
+	assert.Equal(t, []interface{}{}, Of().Apply(flatten).ToSlice())
+	assert.Equal(
+		t,
+		flatten(Of([]interface{}{1, 2}, []interface{}{}, []interface{}{3})).ToSlice(),
+		Of([]interface{}{1, 2}, []interface{}{}, []interface{}{3}).Apply(flatten).ToSlice(),
+	)
+
+	// Chained
+	double := func(it *Iter) *Iter {
+		return New(func() (interface{}, bool) {
+			if !it.Next() {
+				return nil, false
+			}
+
+			return it.IntValue() * 2, true
+		})
+	}
+	assert.Equal(t, []interface{}{2, 4, 6}, Of([]interface{}{1, 2}, []interface{}{3}).Apply(flatten).Apply(double).ToSlice())
+}
+
+func TestAverageFloat64(t *testing.T) {
+	avg, ok := Of().AverageFloat64()
+	assert.Equal(t, 0.0, avg)
+	assert.False(t, ok)
+
+	avg, ok = Of(1, 2.5, uint8(3), 4.5).AverageFloat64()
+	assert.Equal(t, 2.75, avg)
+	assert.True(t, ok)
+}
+
+func TestBatchByTime(t *testing.T) {
+	// Mock clock that returns the given times in order
+	mockClock := func(times ...time.Duration) {
+		var (
+			start = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			idx   int
+		)
+
+		now = func() time.Time {
+			t := start.Add(times[idx])
+			idx++
+			return t
+		}
+	}
+	defer func() { now = time.Now }()
+
+	// Two elements within a window, third in next window
+	mockClock(0, 500*time.Millisecond, 1500*time.Millisecond)
+	assert.Equal(
+		t,
+		[]interface{}{[]interface{}{1, 2}, []interface{}{3}},
+		Of(1, 2, 3).BatchByTime(time.Second).ToSlice(),
+	)
+
+	// Window starts at the first element of each batch
+	mockClock(0, time.Second, 1500*time.Millisecond, 2*time.Second, 2100*time.Millisecond)
+	assert.Equal(
+		t,
+		[]interface{}{[]interface{}{1}, []interface{}{2, 3}, []interface{}{4, 5}},
+		Of(1, 2, 3, 4, 5).BatchByTime(time.Second).ToSlice(),
+	)
+
+	// Empty
+	assert.Equal(t, []interface{}{}, Of().BatchByTime(time.Second).ToSlice())
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrWindowGreaterThanZero, recover())
+		}()
+
+		Of().BatchByTime(0)
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestCycle(t *testing.T) {
+	// Empty source is not infinite
+	iter := Of().Cycle()
+	assert.False(t, iter.Next())
+
+	// Single element
+	iter = Of(1).Cycle()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 1, iter.NextValue())
+	}
+
+	// Two elements
+	iter = Of(1, 2).Cycle()
+	var values []interface{}
+	for i := 0; i < 5; i++ {
+		values = append(values, iter.NextValue())
+	}
+	assert.Equal(t, []interface{}{1, 2, 1, 2, 1}, values)
+}
+
+func TestEachUntil(t *testing.T) {
+	var (
+		seen    []interface{}
+		stopErr = fmt.Errorf("stop")
+		f       = func(element interface{}) error {
+			seen = append(seen, element)
+			if element == 3 {
+				return stopErr
+			}
+
+			return nil
+		}
+	)
+
+	// Exhausted without error
+	assert.Nil(t, Of(1, 2).EachUntil(f))
+	assert.Equal(t, []interface{}{1, 2}, seen)
+
+	// Stops on the third element, iterator can still be read
+	seen = nil
+	iter := Of(1, 2, 3, 4, 5)
+	assert.Equal(t, stopErr, iter.EachUntil(f))
+	assert.Equal(t, []interface{}{1, 2, 3}, seen)
+	assert.Equal(t, []interface{}{4, 5}, iter.ToSlice())
+}
+
+func TestFlattenElements(t *testing.T) {
+	assert.Equal(t, []interface{}{}, Of().FlattenElements().ToSlice())
+	assert.Equal(t, []interface{}{}, Of([]int{}, [0]string{}).FlattenElements().ToSlice())
+	assert.Equal(
+		t,
+		[]interface{}{1, 2, 3, "a", 4, 5, []int{6, 7}, nil, "b"},
+		Of(1, []int{2, 3}, "a", [2]int{4, 5}, []int{}, [][]int{{6, 7}}, nil, []string{"b"}).FlattenElements().ToSlice(),
+	)
+
+	// Lazy: an infinite source of slices
+	var (
+		i        int
+		infinite = New(func() (interface{}, bool) {
+			i++
+			return []int{i, i}, true
+		})
+	)
+	assert.Equal(t, []interface{}{1, 1, 2, 2, 3}, infinite.FlattenElements().ToSliceN(5))
+	assert.Equal(t, 3, i)
+}
+
+func TestIntersperse(t *testing.T) {
+	assert.Equal(t, []interface{}{}, Of().Intersperse(0).ToSlice())
+	assert.Equal(t, []interface{}{1}, Of(1).Intersperse(0).ToSlice())
+	assert.Equal(t, []interface{}{1, 0, 2}, Of(1, 2).Intersperse(0).ToSlice())
+	assert.Equal(t, []interface{}{1, 0, 2, 0, 3}, Of(1, 2, 3).Intersperse(0).ToSlice())
+
+	// Lazy, so it works with an infinite Iter
+	assert.Equal(t, []interface{}{"a", ",", "a", ",", "a"}, Of("a").Cycle().Intersperse(",").ToSliceN(5))
+}
+
+func TestMaxMin(t *testing.T) {
+	val, ok := Of().Max(funcs.IntSortFunc)
+	assert.Nil(t, val)
+	assert.False(t, ok)
+
+	val, ok = Of(1).Max(funcs.IntSortFunc)
+	assert.Equal(t, 1, val)
+	assert.True(t, ok)
+
+	val, ok = Of(2, 3, 1).Max(funcs.IntSortFunc)
+	assert.Equal(t, 3, val)
+	assert.True(t, ok)
+
+	val, ok = Of().Min(funcs.IntSortFunc)
+	assert.Nil(t, val)
+	assert.False(t, ok)
+
+	val, ok = Of(1).Min(funcs.IntSortFunc)
+	assert.Equal(t, 1, val)
+	assert.True(t, ok)
+
+	val, ok = Of(2, 3, 1).Min(funcs.IntSortFunc)
+	assert.Equal(t, 1, val)
+	assert.True(t, ok)
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(element interface{}) bool { return element.(int)%2 == 0 }
+
+	// Empty
+	evens, odds := Of().Partition(isEven)
+	assert.False(t, evens.Next())
+	assert.False(t, odds.Next())
+
+	// Read all of one partition, then the other
+	evens, odds = Of(1, 2, 3, 4, 5).Partition(isEven)
+	assert.Equal(t, []interface{}{2, 4}, evens.ToSlice())
+	assert.Equal(t, []interface{}{1, 3, 5}, odds.ToSlice())
+
+	// Interleaved reads, where each source element is read exactly once
+	var reads []interface{}
+	src := Of(1, 3, 2, 4, 6, 5)
+	counted := New(func() (interface{}, bool) {
+		if src.Next() {
+			val := src.Value()
+			reads = append(reads, val)
+			return val, true
+		}
+
+		return nil, false
+	})
+
+	evens, odds = counted.Partition(isEven)
+	assert.Equal(t, 2, evens.NextValue())
+	assert.Equal(t, []interface{}{1, 3, 2}, reads)
+	assert.Equal(t, 1, odds.NextValue())
+	assert.Equal(t, 3, odds.NextValue())
+	assert.Equal(t, 4, evens.NextValue())
+	assert.Equal(t, 5, odds.NextValue())
+	assert.Equal(t, []interface{}{1, 3, 2, 4, 6, 5}, reads)
+	assert.False(t, odds.Next())
+	assert.Equal(t, 6, evens.NextValue())
+	assert.False(t, evens.Next())
+	assert.Equal(t, []interface{}{1, 3, 2, 4, 6, 5}, reads)
+}
+
 func TestToReader(t *testing.T) {
 	{
 		var (
@@ -955,6 +1572,51 @@ func TestToReader(t *testing.T) {
 	}
 }
 
+func TestScan(t *testing.T) {
+	sum := func(accumulator, element interface{}) interface{} { return accumulator.(int) + element.(int) }
+
+	assert.Equal(t, []interface{}{}, Of().Scan(0, sum).ToSlice())
+	assert.Equal(t, []interface{}{1, 3, 6}, Of(1, 2, 3).Scan(0, sum).ToSlice())
+
+	// Infinite source of 1, 2, 3, ...
+	var n int
+	naturals := New(func() (interface{}, bool) {
+		n++
+		return n, true
+	})
+
+	assert.Equal(t, []interface{}{11, 13, 16, 20}, naturals.Scan(10, sum).ToSliceN(4))
+}
+
+func TestSumFloat64(t *testing.T) {
+	sum, ok := Of().SumFloat64()
+	assert.Equal(t, 0.0, sum)
+	assert.False(t, ok)
+
+	sum, ok = Of(0).SumFloat64()
+	assert.Equal(t, 0.0, sum)
+	assert.True(t, ok)
+
+	sum, ok = Of(1, 2.5, uint8(3), 4.5).SumFloat64()
+	assert.Equal(t, 11.0, sum)
+	assert.True(t, ok)
+}
+
+func TestToSet(t *testing.T) {
+	assert.Equal(t, map[interface{}]struct{}{}, Of().ToSet())
+	assert.Equal(t, map[interface{}]struct{}{1: {}}, Of(1).ToSet())
+	assert.Equal(t, map[interface{}]struct{}{1: {}, 2: {}, "3": {}}, Of(1, 2, 1, "3", 2, "3").ToSet())
+
+	func() {
+		defer func() {
+			assert.NotNil(t, recover())
+		}()
+
+		Of([]int{1}).ToSet()
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestToSlice(t *testing.T) {
 	assert.Equal(t, []interface{}{}, Of().ToSlice())
 	assert.Equal(t, []interface{}{1}, Of(1).ToSlice())
@@ -972,6 +1634,20 @@ func TestToSlice(t *testing.T) {
 	}()
 }
 
+func TestToSliceN(t *testing.T) {
+	assert.Equal(t, []interface{}{}, Of().ToSliceN(2))
+	assert.Equal(t, []interface{}{}, Of(1).ToSliceN(0))
+	assert.Equal(t, []interface{}{1}, Of(1).ToSliceN(2))
+
+	// Collect 2 of 5, then continue reading the remaining 3
+	iter := Of(1, 2, 3, 4, 5)
+	assert.Equal(t, []interface{}{1, 2}, iter.ToSliceN(2))
+	assert.True(t, iter.Next())
+	assert.Equal(t, 3, iter.Value())
+	assert.Equal(t, []interface{}{4, 5}, iter.ToSliceN(3))
+	assert.False(t, iter.Next())
+}
+
 func TestToSliceOf(t *testing.T) {
 	assert.Equal(t, []int{}, Of().ToSliceOf(0))
 	assert.Equal(t, []int{1}, Of(1).ToSliceOf(0))
@@ -989,6 +1665,30 @@ func TestToSliceOf(t *testing.T) {
 	}()
 }
 
+func TestToStringSlice(t *testing.T) {
+	assert.Equal(t, []string{}, Of().ToStringSlice())
+	assert.Equal(t, []string{"a"}, Of("a").ToStringSlice())
+
+	const lines = "one\ntwo\r\nthree"
+	assert.Equal(t, []string{"one", "two", "three"}, OfReaderLines(strings.NewReader(lines)).ToStringSlice())
+	assert.Equal(
+		t,
+		OfReaderLines(strings.NewReader(lines)).ToSliceOf("").([]string),
+		OfReaderLines(strings.NewReader(lines)).ToStringSlice(),
+	)
+
+	iter := Of()
+	iter.ToStringSlice()
+	func() {
+		defer func() {
+			assert.Equal(t, ErrValueExhaustedIter, recover())
+		}()
+
+		iter.Value()
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestForLoop(t *testing.T) {
 	{
 		var (
@@ -3,9 +3,21 @@
 package stream
 
 import (
+	"bufio"
+	"compress/gzip"
+	"container/heap"
+	"container/list"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
 	"io"
+	"math/big"
 	"reflect"
 	"sort"
+	"strings"
+	"sync"
 
 	"github.com/bantling/gomicro/iter"
 	"github.com/bantling/gomicro/optional"
@@ -57,6 +69,51 @@ func (fin Finisher) Transform(g func() func(*iter.Iter) *iter.Iter) Finisher {
 	return fin
 }
 
+// CoalesceRuns composes the current generator with a generator that merges runs of adjacent elements.
+// While canMerge(prev, cur) is true, cur is folded into prev by replacing prev with merge(prev, cur).
+// When canMerge returns false, or there are no more elements, prev is iterated as the result of the run.
+func (fin Finisher) CoalesceRuns(
+	canMerge func(prev, cur interface{}) bool,
+	merge func(prev, cur interface{}) interface{},
+) Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				var (
+					prev     interface{}
+					havePrev bool
+				)
+
+				return iter.New(
+					func() (interface{}, bool) {
+						for it.Next() {
+							cur := it.Value()
+
+							switch {
+							case !havePrev:
+								prev, havePrev = cur, true
+							case canMerge(prev, cur):
+								prev = merge(prev, cur)
+							default:
+								result := prev
+								prev = cur
+								return result, true
+							}
+						}
+
+						if havePrev {
+							havePrev = false
+							return prev, true
+						}
+
+						return nil, false
+					},
+				)
+			}
+		},
+	)
+}
+
 // Distinct composes the current generator with a generator of distinct elements only.
 // The order of the result is the first occurence of each distinct element.
 // Elements must be a type compatible with a map key.
@@ -77,6 +134,134 @@ func (fin Finisher) Distinct() Finisher {
 	)
 }
 
+// DistinctBounded composes the current generator with a generator of elements that are distinct from the most recent maxKeys distinct elements.
+// Unlike Distinct, the memory used is bounded, by keeping the distinct elements in a least recently used cache of size maxKeys.
+// This is an approximation of Distinct: an element that is evicted from the cache may reappear in the result.
+// An element that is seen again while it is still in the cache becomes the most recently used element.
+// Elements must be a type compatible with a map key.
+// Panics if maxKeys <= 0.
+func (fin Finisher) DistinctBounded(maxKeys int) Finisher {
+	if maxKeys <= 0 {
+		panic(ErrInvalidMaxKeys)
+	}
+
+	return fin.Filter(
+		func() func(element interface{}) bool {
+			var (
+				// Most recently used elements are at the front
+				recent   = list.New()
+				elements = map[interface{}]*list.Element{}
+			)
+
+			return func(element interface{}) bool {
+				if e, haveIt := elements[element]; haveIt {
+					recent.MoveToFront(e)
+					return false
+				}
+
+				if recent.Len() == maxKeys {
+					delete(elements, recent.Remove(recent.Back()))
+				}
+
+				elements[element] = recent.PushFront(element)
+				return true
+			}
+		},
+	)
+}
+
+// DistinctFunc composes the current generator with a generator of distinct elements only, according to the given equality function.
+// The order of the result is the first occurence of each distinct element.
+// Unlike Distinct, elements do not have to be a type compatible with a map key, so that slices or structs containing slices can be compared.
+// The tradeoff is that each element is compared with every distinct element read so far, so the complexity is O(n²) rather than O(n).
+func (fin Finisher) DistinctFunc(eq func(element1, element2 interface{}) bool) Finisher {
+	return fin.Filter(
+		func() func(element interface{}) bool {
+			var alreadyRead []interface{}
+
+			return func(element interface{}) bool {
+				for _, read := range alreadyRead {
+					if eq(read, element) {
+						return false
+					}
+				}
+
+				alreadyRead = append(alreadyRead, element)
+				return true
+			}
+		},
+	)
+}
+
+// DistinctLast composes the current generator with a generator of distinct elements only.
+// Unlike Distinct, the order of the result is the last occurence of each distinct element, for "latest wins" semantics.
+// Since the last occurrence is not known until all elements have been read, all elements are buffered.
+// Elements must be a type compatible with a map key.
+func (fin Finisher) DistinctLast() Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				var distinctIter *iter.Iter
+
+				return iter.New(
+					func() (interface{}, bool) {
+						if distinctIter == nil {
+							// Buffer all elements, and record the index of the last occurence of each element
+							var (
+								elements  = it.ToSlice()
+								lastIndex = map[interface{}]int{}
+								distinct  []interface{}
+							)
+
+							for i, element := range elements {
+								lastIndex[element] = i
+							}
+
+							for i, element := range elements {
+								if lastIndex[element] == i {
+									distinct = append(distinct, element)
+								}
+							}
+
+							distinctIter = iter.OfElements(distinct)
+						}
+
+						if distinctIter.Next() {
+							return distinctIter.Value(), true
+						}
+
+						return nil, false
+					},
+				)
+			}
+		},
+	)
+}
+
+// DistinctUntilChangedBy composes the current generator with a generator that suppresses consecutive elements with the same key.
+// An element is only iterated if the key returned by keyFn differs from the key of the previous element.
+// Unlike Distinct, only adjacent elements are compared, so the elements do not have to be comparable - only the keys do.
+func (fin Finisher) DistinctUntilChangedBy(keyFn func(element interface{}) (key interface{})) Finisher {
+	return fin.Filter(
+		func() func(element interface{}) bool {
+			var (
+				lastKey  interface{}
+				haveLast bool
+			)
+
+			return func(element interface{}) bool {
+				key := keyFn(element)
+				if haveLast && (key == lastKey) {
+					return false
+				}
+
+				lastKey, haveLast = key, true
+				return true
+			}
+		},
+	)
+}
+
 // Duplicate composes the current generator with a generator of duplicate elements only.
 // The order of the result is the second occurence of each duplicate element.
 // Elements must be a type compatible with a map key.
@@ -97,6 +282,63 @@ func (fin Finisher) Duplicate() Finisher {
 	)
 }
 
+// DuplicateFunc composes the current generator with a generator of duplicate elements only, according to the given equality function.
+// The order of the result is the second occurence of each duplicate element.
+// Unlike Duplicate, elements do not have to be a type compatible with a map key, so that slices or structs containing slices can be compared.
+// The tradeoff is that each element is compared with every distinct element read so far, so the complexity is O(n²) rather than O(n).
+func (fin Finisher) DuplicateFunc(eq func(element1, element2 interface{}) bool) Finisher {
+	return fin.Filter(
+		func() func(element interface{}) bool {
+			var alreadyRead []interface{}
+
+			return func(element interface{}) bool {
+				for _, read := range alreadyRead {
+					if eq(read, element) {
+						return true
+					}
+				}
+
+				alreadyRead = append(alreadyRead, element)
+				return false
+			}
+		},
+	)
+}
+
+// EnumerateByGroup composes the current generator with a generator that numbers elements within groups of adjacent elements with the same key.
+// Each element is iterated as an iter.KeyValue, where Key is the sequence number of the element within its group, and Value is the element.
+// The sequence number starts at 0, and resets to 0 each time the key returned by keyFn differs from the key of the previous element.
+func (fin Finisher) EnumerateByGroup(keyFn func(element interface{}) (key interface{})) Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			var (
+				lastKey  interface{}
+				haveLast bool
+				seq      int
+			)
+
+			return func(it *iter.Iter) *iter.Iter {
+				return iter.New(
+					func() (interface{}, bool) {
+						if !it.Next() {
+							return nil, false
+						}
+
+						val := it.Value()
+						if key := keyFn(val); haveLast && (key == lastKey) {
+							seq++
+						} else {
+							lastKey, haveLast, seq = key, true, 0
+						}
+
+						return iter.KeyValue{Key: seq, Value: val}, true
+					},
+				)
+			}
+		},
+	)
+}
+
 // Filter composes the current generator with a filter of all elements that pass the given predicate generator
 func (fin Finisher) Filter(g func() func(element interface{}) bool) Finisher {
 	return fin.Transform(
@@ -167,6 +409,181 @@ func (fin Finisher) Limit(n uint) Finisher {
 	)
 }
 
+// LimitBytes composes the current generator with a generator that converts each element to a byte with ByteValue,
+// and stops once max bytes have been iterated, so that untrusted input cannot be read without limit.
+// Since a generator is used, the count is shared by all elements of a terminal call, even if a ParallelConfig is provided.
+// Unless the optional ParallelConfig is provided, no more elements are read from the source once max bytes have been iterated.
+// Unlike Limit, the elements are converted to bytes.
+// Panics with ErrNegativeLimitBytes if max < 0.
+// Panics if an element is not convertible to a byte.
+func (fin Finisher) LimitBytes(max int) Finisher {
+	if max < 0 {
+		panic(ErrNegativeLimitBytes)
+	}
+
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				count := 0
+
+				return iter.New(
+					func() (interface{}, bool) {
+						if (count < max) && it.Next() {
+							count++
+							return it.ByteValue(), true
+						}
+
+						return nil, false
+					},
+				)
+			}
+		},
+	)
+}
+
+// MapEveryNth composes the current generator with a generator that maps every nth element with the given function,
+// where the first element is element 1. All other elements are passed through unchanged.
+// EG, if n is 2, then the 2nd, 4th, 6th, ... elements are mapped.
+// Since a generator is used, elements are counted across all chunks of a parallel execution, and each terminal call starts counting again.
+// Panics with ErrInvalidEveryNth if n is 0.
+func (fin Finisher) MapEveryNth(n uint, f func(element interface{}) interface{}) Finisher {
+	if n == 0 {
+		panic(ErrInvalidEveryNth)
+	}
+
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				var count uint
+
+				return iter.New(
+					func() (interface{}, bool) {
+						if !it.Next() {
+							return nil, false
+						}
+
+						val := it.Value()
+						if count++; count == n {
+							count = 0
+							return f(val), true
+						}
+
+						return val, true
+					},
+				)
+			}
+		},
+	)
+}
+
+// MapStateful composes the current generator with a generator that maps each element with a state that is carried across elements.
+// For each element, f is called with the current state and the element, and returns the new state and the output to iterate.
+// The state is initial for the first element, and since a generator is used, each terminal call starts again with initial.
+func (fin Finisher) MapStateful(
+	initial interface{},
+	f func(state, element interface{}) (newState, output interface{}),
+) Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				state := initial
+
+				return iter.New(
+					func() (interface{}, bool) {
+						if !it.Next() {
+							return nil, false
+						}
+
+						var output interface{}
+						state, output = f(state, it.Value())
+						return output, true
+					},
+				)
+			}
+		},
+	)
+}
+
+// MergeAdjacentByKey composes the current generator with a generator that merges runs of adjacent iter.KeyValue elements with the same Key.
+// The Values of each run are folded with combine, and one iter.KeyValue is iterated per run with the Key and the folded Value.
+// Typically, the elements are sorted by Key first, so that each Key has only one run.
+// Panics if adjacent elements are not iter.KeyValue.
+func (fin Finisher) MergeAdjacentByKey(combine func(value1, value2 interface{}) interface{}) Finisher {
+	return fin.CoalesceRuns(
+		func(prev, cur interface{}) bool {
+			return prev.(iter.KeyValue).Key == cur.(iter.KeyValue).Key
+		},
+		func(prev, cur interface{}) interface{} {
+			kv := prev.(iter.KeyValue)
+			return iter.KeyValue{Key: kv.Key, Value: combine(kv.Value, cur.(iter.KeyValue).Value)}
+		},
+	)
+}
+
+// Pairwise composes the current generator with a generator of each pair of adjacent elements,
+// as an iter.KeyValue where Key is the previous element and Value is the current element.
+// EG, 1, 2, 3 produces {1, 2}, {2, 3}. There are n - 1 pairs for n elements, so less than 2 elements produces no pairs.
+func (fin Finisher) Pairwise() Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				var (
+					prev     interface{}
+					havePrev bool
+				)
+
+				return iter.New(
+					func() (interface{}, bool) {
+						// Read first element of first pair
+						if !havePrev {
+							if !it.Next() {
+								return nil, false
+							}
+
+							prev, havePrev = it.Value(), true
+						}
+
+						if !it.Next() {
+							return nil, false
+						}
+
+						cur := it.Value()
+						pair := iter.KeyValue{Key: prev, Value: cur}
+						prev = cur
+						return pair, true
+					},
+				)
+			}
+		},
+	)
+}
+
+// PeekIndexed composes the current generator with a generator that calls a function that examines each element with its zero based index
+// and performs an additional operation. Each element is passed through unchanged.
+// Since a generator is used, f is called serially with indexes 0..n-1 in order, even if a ParallelConfig is provided.
+func (fin Finisher) PeekIndexed(f func(index int, element interface{})) Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				index := 0
+
+				return iter.New(
+					func() (interface{}, bool) {
+						if it.Next() {
+							val := it.Value()
+							f(index, val)
+							index++
+							return val, true
+						}
+
+						return nil, false
+					},
+				)
+			}
+		},
+	)
+}
+
 // ReverseSort composes the current generator with a generator that sorts the values by the provided comparator in reverse order.
 // The provided function must compare elements in increasing order, same as for Sorted.
 func (fin Finisher) ReverseSort(less func(element1, element2 interface{}) bool) Finisher {
@@ -175,8 +592,14 @@ func (fin Finisher) ReverseSort(less func(element1, element2 interface{}) bool)
 	})
 }
 
-// Skip composes the current generator with a generator that skips the first n elements
+// Skip composes the current generator with a generator that skips the first n elements.
+// If n is 0, no elements are skipped.
+// Panics if n < 0.
 func (fin Finisher) Skip(n int) Finisher {
+	if n < 0 {
+		panic(ErrNegativeSkip)
+	}
+
 	return fin.Transform(
 		func() func(it *iter.Iter) *iter.Iter {
 			skipped := false
@@ -246,25 +669,210 @@ func (fin Finisher) Sort(less func(element1, element2 interface{}) bool) Finishe
 	)
 }
 
+// SplitBy composes the current generator with a generator that splits the elements into groups separated by separator elements.
+// Each group is iterated as a []interface{} of the elements between separators, and the separators are dropped.
+// As with strings.Split, consecutive separators produce empty groups, and a separator at the start or end produces an empty group before or after it.
+// If there are no elements, there are no groups.
+func (fin Finisher) SplitBy(isSep func(element interface{}) bool) Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				var (
+					started bool
+					done    bool
+				)
+
+				return iter.New(
+					func() (interface{}, bool) {
+						if done {
+							return nil, false
+						}
+
+						group := []interface{}{}
+						for it.Next() {
+							started = true
+
+							val := it.Value()
+							if isSep(val) {
+								return group, true
+							}
+
+							group = append(group, val)
+						}
+
+						// Last group, which is empty if the last element is a separator
+						done = true
+						if !started {
+							return nil, false
+						}
+
+						return group, true
+					},
+				)
+			}
+		},
+	)
+}
+
+// SplitLarge composes the current generator with a generator that calls splitter for each element to break it into pieces of at most maxSize.
+// The pieces are iterated in the order splitter returns them, so an element that does not need splitting can be returned as a single piece.
+func (fin Finisher) SplitLarge(maxSize int, splitter func(element interface{}, maxSize int) []interface{}) Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				var pieces []interface{}
+
+				return iter.New(
+					func() (interface{}, bool) {
+						for len(pieces) == 0 {
+							if !it.Next() {
+								return nil, false
+							}
+
+							pieces = splitter(it.Value(), maxSize)
+						}
+
+						piece := pieces[0]
+						pieces = pieces[1:]
+						return piece, true
+					},
+				)
+			}
+		},
+	)
+}
+
+// Until composes the current generator with a generator that passes each element through unchanged until pred returns true for an element.
+// The element that satisfies pred is not passed through, and no further elements are iterated.
+// Unless the optional ParallelConfig is provided, no further elements are read from the source.
+// Useful for terminating a stream at a sentinel, such as an end of stream marker.
+func (fin Finisher) Until(pred func(element interface{}) bool) Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				done := false
+
+				return iter.New(
+					func() (interface{}, bool) {
+						if !done && it.Next() {
+							if val := it.Value(); !pred(val) {
+								return val, true
+							}
+						}
+
+						done = true
+						return nil, false
+					},
+				)
+			}
+		},
+	)
+}
+
+// WithIndex composes the current generator with a generator that maps each element to an iter.KeyValue,
+// where Key is the index of the element and Value is the element.
+// The index of the first element is start, and the index increments by one for each element.
+// Indexes reflect the order of elements after any prior transforms, such as Filter.
+// Since a generator is used, each terminal call starts again at start, and indexes are unique even if a ParallelConfig is provided.
+func (fin Finisher) WithIndex(start int) Finisher {
+	return fin.Transform(
+		func() func(it *iter.Iter) *iter.Iter {
+			return func(it *iter.Iter) *iter.Iter {
+				index := start
+
+				return iter.New(
+					func() (interface{}, bool) {
+						if it.Next() {
+							kv := iter.KeyValue{Key: index, Value: it.Value()}
+							index++
+							return kv, true
+						}
+
+						return nil, false
+					},
+				)
+			}
+		},
+	)
+}
+
 //
 // ==== Terminals
 //
 
+// lessHeap is a heap.Interface of elements ordered by a less function, so that the smallest element is at the top
+type lessHeap struct {
+	less     func(element1, element2 interface{}) bool
+	elements []interface{}
+}
+
+func (h *lessHeap) Len() int { return len(h.elements) }
+
+func (h *lessHeap) Less(i, j int) bool { return h.less(h.elements[i], h.elements[j]) }
+
+func (h *lessHeap) Swap(i, j int) { h.elements[i], h.elements[j] = h.elements[j], h.elements[i] }
+
+func (h *lessHeap) Push(x interface{}) { h.elements = append(h.elements, x) }
+
+func (h *lessHeap) Pop() interface{} {
+	n := len(h.elements) - 1
+	element := h.elements[n]
+	h.elements = h.elements[:n]
+	return element
+}
+
+// topN returns the n largest elements of an Iter according to less, in descending order, using a bounded heap of the largest elements so far.
+// If n <= 0, an empty slice is returned.
+func topN(n int, less func(element1, element2 interface{}) bool, it *iter.Iter) []interface{} {
+	if n <= 0 {
+		return []interface{}{}
+	}
+
+	h := &lessHeap{less: less}
+	for it.Next() {
+		element := it.Value()
+
+		switch {
+		case h.Len() < n:
+			heap.Push(h, element)
+		case less(h.elements[0], element):
+			// Replace the smallest of the largest elements so far
+			h.elements[0] = element
+			heap.Fix(h, 0)
+		}
+	}
+
+	// Pop smallest first into the end of the result, so the result is in descending order
+	result := make([]interface{}, h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h)
+	}
+
+	return result
+}
+
 // Iter returns an iterator of the elements in the given source after applying the transforms in this Finisher.
-// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before returning the Iter.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before returning the Iter,
+// unless the Flags are Serial. If the ParallelConfig has a Progress callback, it is called as the elements are processed.
 func (fin Finisher) Iter(source *iter.Iter, pc ...ParallelConfig) *iter.Iter {
-	var it *iter.Iter
+	var (
+		it    *iter.Iter
+		pconf ParallelConfig
+	)
 
 	if len(pc) > 0 {
-		// Parallel execution
-		pconf := pc[0]
+		pconf = pc[0]
+	}
 
+	if (len(pc) > 0) && (pconf.Flags != Serial) {
+		// Parallel execution
 		data := doParallel(
 			source,
 			fin.stream.transform,
 			fin.generator,
 			pconf.NumberOfItems,
 			pconf.Flags,
+			newProgressCounter(pconf.Progress, pconf.ProgressInterval),
 		)
 
 		it = iter.Of(data...)
@@ -279,6 +887,21 @@ func (fin Finisher) Iter(source *iter.Iter, pc ...ParallelConfig) *iter.Iter {
 		if fin.generator != nil {
 			it = fin.generator()(it)
 		}
+
+		if progress := newProgressCounter(pconf.Progress, pconf.ProgressInterval); progress != nil {
+			// Count transformed elements as they are read, and report the final count once they are exhausted
+			counted := progress.countIter(it)
+			it = iter.New(
+				func() (interface{}, bool) {
+					if counted.Next() {
+						return counted.Value(), true
+					}
+
+					progress.done()
+					return nil, false
+				},
+			)
+		}
 	}
 
 	return it
@@ -310,6 +933,24 @@ func (fin Finisher) AnyMatch(f func(element interface{}) bool, source *iter.Iter
 	return anyMatch
 }
 
+// AssociateBy maps elements by executing the given function on each value to get a key, and associating the key with the element.
+// If more than one element has the same key, the last such element is the one associated with the key.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before mapping.
+func (fin Finisher) AssociateBy(
+	keyFn func(element interface{}) (key interface{}),
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) map[interface{}]interface{} {
+	m := map[interface{}]interface{}{}
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		element := it.Value()
+		m[keyFn(element)] = element
+	}
+
+	return m
+}
+
 // Average returns an optional average value.
 // The slice elements must be convertible to a float64.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before the calculation.
@@ -332,9 +973,72 @@ func (fin Finisher) Average(source *iter.Iter, pc ...ParallelConfig) optional.Op
 	return optional.Of(avg)
 }
 
+// AverageAsBigRat returns an optional average value as a *big.Rat, calculated without any loss of precision.
+// The slice elements must be ints, uints, floats, math/big numbers, or json.Numbers.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before the calculation.
+func (fin Finisher) AverageAsBigRat(source *iter.Iter, pc ...ParallelConfig) optional.Optional {
+	var (
+		sum   = new(big.Rat)
+		count int64
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		sum.Add(sum, toBigRat(it.Value()))
+		count++
+	}
+
+	if count == 0 {
+		return optional.Of()
+	}
+
+	avg := sum.Quo(sum, new(big.Rat).SetInt64(count))
+	return optional.Of(avg)
+}
+
+// Collect performs a custom aggregation of all elements using three functions:
+// - supplier creates the initial accumulator
+// - accumulator folds each element into the accumulator, returning the resulting accumulator
+// - finisher converts the final accumulator into the result
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before aggregating.
+func (fin Finisher) Collect(
+	supplier func() interface{},
+	accumulator func(acc, element interface{}) interface{},
+	finisher func(acc interface{}) interface{},
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) interface{} {
+	acc := supplier()
+	for it := fin.Iter(source, pc...); it.Next(); {
+		acc = accumulator(acc, it.Value())
+	}
+
+	return finisher(acc)
+}
+
+// CollectMulti feeds each element to every collector in the order given, so that multiple results can be built in a single pass.
+// EG, one collector can append elements to a slice, another can sum them, and another can insert them into a map.
+// Since a variadic parameter must be last, the collectors are passed as a slice.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before collecting.
+func (fin Finisher) CollectMulti(collectors []func(element interface{}), source *iter.Iter, pc ...ParallelConfig) {
+	for it := fin.Iter(source, pc...); it.Next(); {
+		element := it.Value()
+		for _, collector := range collectors {
+			collector(element)
+		}
+	}
+}
+
 // Count returns the count of all elements.
+// If there are no transforms and no ParallelConfig, and the source knows how many elements it has (see iter.Iter.Len),
+// the count is returned without iterating the source, otherwise the elements are iterated to count them.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before counting.
 func (fin Finisher) Count(source *iter.Iter, pc ...ParallelConfig) int {
+	if (fin.stream.transform == nil) && (fin.generator == nil) && (len(pc) == 0) {
+		if n := source.Len(); n >= 0 {
+			return n
+		}
+	}
+
 	count := 0
 	for it := fin.Iter(source, pc...); it.Next(); {
 		it.Value()
@@ -344,6 +1048,13 @@ func (fin Finisher) Count(source *iter.Iter, pc ...ParallelConfig) int {
 	return count
 }
 
+// Find returns the optional first element of applying any transforms to the stream source that passes the given predicate.
+// It is the same as FirstMatch, for those used to the name Find.
+// Unless the optional ParallelConfig is provided, no further elements are read once a match is found.
+func (fin Finisher) Find(pred func(element interface{}) bool, source *iter.Iter, pc ...ParallelConfig) optional.Optional {
+	return fin.FirstMatch(pred, source, pc...)
+}
+
 // First returns the optional first element of applying any tranforms to the stream source.
 // Note that an empty Optional means either the first element is nil, or the stream is empty.
 func (fin Finisher) First(source *iter.Iter, pc ...ParallelConfig) optional.Optional {
@@ -356,6 +1067,41 @@ func (fin Finisher) First(source *iter.Iter, pc ...ParallelConfig) optional.Opti
 	return optional.Of(val)
 }
 
+// FirstMatch returns the optional first element of applying any transforms to the stream source that passes the given predicate.
+// Note that an empty Optional means either the first matching element is nil, or no element matches.
+// Unless the optional ParallelConfig is provided, no further elements are read once a match is found.
+func (fin Finisher) FirstMatch(pred func(element interface{}) bool, source *iter.Iter, pc ...ParallelConfig) optional.Optional {
+	for it := fin.Iter(source, pc...); it.Next(); {
+		if val := it.Value(); pred(val) {
+			return optional.Of(val)
+		}
+	}
+
+	return optional.Of()
+}
+
+// FlattenToSlice returns a slice of all elements, where elements that are arrays or slices are replaced by their elements.
+// Other elements are added to the slice as is.
+// Only one level is flattened, so an element that is a two dimensional slice contributes a slice for each row.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before flattening.
+func (fin Finisher) FlattenToSlice(source *iter.Iter, pc ...ParallelConfig) []interface{} {
+	array := []interface{}{}
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		val := it.Value()
+
+		if rv := reflect.ValueOf(val); (rv.Kind() == reflect.Array) || (rv.Kind() == reflect.Slice) {
+			for i, n := 0, rv.Len(); i < n; i++ {
+				array = append(array, rv.Index(i).Interface())
+			}
+		} else {
+			array = append(array, val)
+		}
+	}
+
+	return array
+}
+
 // ForEach invokes a consumer with each element of the stream.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before invoking the consumer.
 func (fin Finisher) ForEach(f func(element interface{}), source *iter.Iter, pc ...ParallelConfig) {
@@ -364,6 +1110,59 @@ func (fin Finisher) ForEach(f func(element interface{}), source *iter.Iter, pc .
 	}
 }
 
+// ForEachParallel invokes a consumer with each element of the stream, using the given number of goroutines.
+// Unlike ForEach with a ParallelConfig, which only executes the transforms in parallel, the consumer itself is invoked concurrently,
+// which is useful for independent side effects such as network requests.
+// The transformed elements are collected before invoking the consumer, and the elements are not consumed in any particular order.
+// The consumer must be safe to call from multiple goroutines.
+// Returns once all elements have been consumed.
+// If the consumer panics, the first panic is recovered, and ForEachParallel panics with the same value once all goroutines are done.
+// Panics with ErrInvalidConcurrency if concurrency <= 0.
+func (fin Finisher) ForEachParallel(f func(element interface{}), concurrency int, source *iter.Iter) {
+	if concurrency <= 0 {
+		panic(ErrInvalidConcurrency)
+	}
+
+	var (
+		elements  = make(chan interface{})
+		wg        = &sync.WaitGroup{}
+		panicOnce sync.Once
+		panicVal  interface{}
+		panicked  bool
+	)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for element := range elements {
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							panicOnce.Do(func() { panicVal, panicked = r, true })
+						}
+					}()
+
+					f(element)
+				}()
+			}
+		}()
+	}
+
+	for _, element := range fin.ToSlice(source) {
+		elements <- element
+	}
+	close(elements)
+
+	wg.Wait()
+
+	if panicked {
+		panic(panicVal)
+	}
+}
+
 // GroupBy groups elements by executing the given function on each value to get a key,
 // and appending the element to the end of a slice associated with the key in the resulting map.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before grouping.
@@ -388,6 +1187,83 @@ func (fin Finisher) GroupBy(
 	return m
 }
 
+// GroupByJoin groups elements by executing the given function on each value to get a key,
+// and joins the string form of the elements for each key with the given separator in the order they occur.
+// Elements are converted to strings with iter.Iter.StringValue.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before grouping.
+// Panics with an iter.ConversionError if elements are not convertible to string.
+func (fin Finisher) GroupByJoin(
+	f func(element interface{}) (key interface{}),
+	sep string,
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) map[interface{}]string {
+	var (
+		groups = map[interface{}][]string{}
+		m      = map[interface{}]string{}
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		val := it.Value()
+		k := f(val)
+		groups[k] = append(groups[k], iter.Of(val).NextStringValue())
+	}
+
+	for k, strs := range groups {
+		m[k] = strings.Join(strs, sep)
+	}
+
+	return m
+}
+
+// GroupByMulti groups elements by multiple levels of keys, where the first function gives the key of the first level,
+// the second function gives the key of the second level within each first level group, and so on.
+// The result is a nested map[interface{}]interface{} for each level, where the values of the last level are []interface{}
+// of the elements in the order they occur. EG, for two levels the result is a map[interface{}]interface{} whose values
+// are map[interface{}]interface{} whose values are []interface{}.
+// If no functions are provided, the result is just a []interface{} of all elements.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before grouping.
+func (fin Finisher) GroupByMulti(
+	keyFns []func(element interface{}) (key interface{}),
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) interface{} {
+	if len(keyFns) == 0 {
+		return fin.ToSlice(source, pc...)
+	}
+
+	var (
+		lastLevel = len(keyFns) - 1
+		m         = map[interface{}]interface{}{}
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		var (
+			element = it.Value()
+			level   = m
+		)
+
+		// Find or create the nested map for each level except the last
+		for _, keyFn := range keyFns[:lastLevel] {
+			k := keyFn(element)
+			next, haveIt := level[k].(map[interface{}]interface{})
+			if !haveIt {
+				next = map[interface{}]interface{}{}
+				level[k] = next
+			}
+
+			level = next
+		}
+
+		// Append element to slice of last level
+		k := keyFns[lastLevel](element)
+		group, _ := level[k].([]interface{})
+		level[k] = append(group, element)
+	}
+
+	return m
+}
+
 // Last returns the optional last element.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before finding the last element.
 func (fin Finisher) Last(source *iter.Iter, pc ...ParallelConfig) optional.Optional {
@@ -399,6 +1275,20 @@ func (fin Finisher) Last(source *iter.Iter, pc ...ParallelConfig) optional.Optio
 	return optional.Of(last)
 }
 
+// LastMatch returns the optional last element that passes the given predicate.
+// Note that an empty Optional means either the last matching element is nil, or no element matches.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before finding the last match.
+func (fin Finisher) LastMatch(pred func(element interface{}) bool, source *iter.Iter, pc ...ParallelConfig) optional.Optional {
+	var last interface{}
+	for it := fin.Iter(source, pc...); it.Next(); {
+		if val := it.Value(); pred(val) {
+			last = val
+		}
+	}
+
+	return optional.Of(last)
+}
+
 // Max returns an optional maximum value according to the provided comparator.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before finding the maximum.
 func (fin Finisher) Max(less func(element1, element2 interface{}) bool, source *iter.Iter, pc ...ParallelConfig) optional.Optional {
@@ -418,6 +1308,14 @@ func (fin Finisher) Max(less func(element1, element2 interface{}) bool, source *
 	return optional.Of(max)
 }
 
+// MaxN returns the n largest elements according to the provided comparator, in descending order.
+// If there are fewer than n elements, all of them are returned in descending order.
+// Only n elements are kept in a bounded heap, so the memory cost is O(n) regardless of the number of elements.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before finding the largest elements.
+func (fin Finisher) MaxN(n int, less func(element1, element2 interface{}) bool, source *iter.Iter, pc ...ParallelConfig) []interface{} {
+	return topN(n, less, fin.Iter(source, pc...))
+}
+
 // Min returns an optional minimum value according to the provided comparator.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before finding the minimum.
 func (fin Finisher) Min(less func(element1, element2 interface{}) bool, source *iter.Iter, pc ...ParallelConfig) optional.Optional {
@@ -428,13 +1326,55 @@ func (fin Finisher) Min(less func(element1, element2 interface{}) bool, source *
 		for it.Next() {
 			element := it.Value()
 
-			if less(element, min) {
-				min = element
-			}
+			if less(element, min) {
+				min = element
+			}
+		}
+	}
+
+	return optional.Of(min)
+}
+
+// MinN returns the n smallest elements according to the provided comparator, in ascending order.
+// If there are fewer than n elements, all of them are returned in ascending order.
+// Only n elements are kept in a bounded heap, so the memory cost is O(n) regardless of the number of elements.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before finding the smallest elements.
+func (fin Finisher) MinN(n int, less func(element1, element2 interface{}) bool, source *iter.Iter, pc ...ParallelConfig) []interface{} {
+	return topN(n, func(element1, element2 interface{}) bool { return less(element2, element1) }, fin.Iter(source, pc...))
+}
+
+// Mode returns an optional of the most frequently occurring element.
+// If more than one element occurs the most often, the tie is broken by choosing the element that occurs first.
+// An empty optional is returned if there are no elements.
+// Elements must be a type compatible with a map key.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before counting.
+func (fin Finisher) Mode(source *iter.Iter, pc ...ParallelConfig) optional.Optional {
+	var (
+		counts = map[interface{}]int{}
+		// Distinct elements in order of first occurrence
+		distinct []interface{}
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		element := it.Value()
+		if counts[element]++; counts[element] == 1 {
+			distinct = append(distinct, element)
+		}
+	}
+
+	if len(distinct) == 0 {
+		return optional.Of()
+	}
+
+	// Only replace the mode when an element occurs strictly more often, so that the first element wins a tie
+	mode := distinct[0]
+	for _, element := range distinct[1:] {
+		if counts[element] > counts[mode] {
+			mode = element
 		}
 	}
 
-	return optional.Of(min)
+	return optional.Of(mode)
 }
 
 // NoneMatch is true if the predicate matches none of the elements with short-circuit logic.
@@ -450,6 +1390,48 @@ func (fin Finisher) NoneMatch(f func(element interface{}) bool, source *iter.Ite
 	return noneMatch
 }
 
+// Product returns an optional product value.
+// The slice elements must be convertible to a float64.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before the calculation.
+func (fin Finisher) Product(source *iter.Iter, pc ...ParallelConfig) optional.Optional {
+	var (
+		product    float64 = 1
+		hasProduct bool
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		product *= it.Float64Value()
+		hasProduct = true
+	}
+
+	if !hasProduct {
+		return optional.Of()
+	}
+
+	return optional.Of(product)
+}
+
+// ProductAsInt returns an optional product value.
+// The slice elements must be convertible to an int.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before the calculation.
+func (fin Finisher) ProductAsInt(source *iter.Iter, pc ...ParallelConfig) optional.Optional {
+	var (
+		product    = 1
+		hasProduct bool
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		product *= it.IntValue()
+		hasProduct = true
+	}
+
+	if !hasProduct {
+		return optional.Of()
+	}
+
+	return optional.Of(product)
+}
+
 // Reduce uses a function to reduce the stream to a single value by iteratively executing a function
 // with the current accumulated value and the next stream element.
 // The identity provided is the initial accumulated value, which means the result type is the
@@ -471,6 +1453,150 @@ func (fin Finisher) Reduce(
 	return result
 }
 
+// ReduceParallel reduces the stream to a single value like Reduce, except that the reduction itself is also executed in parallel.
+// The transformed data set is split into chunks of contiguous elements, each chunk is reduced independently in a separate goroutine
+// starting with the identity, and the partial results are merged in order with mergeAccs.
+//
+// For the result to be the same as a serial reduction:
+// - combine and mergeAccs must be associative, EG sum or concatenation
+// - identity must be an identity of combine, EG 0 for a sum, as it is the initial value of every chunk
+// - the identity must not be modified by combine, as it is shared by all chunks
+//
+// If there are no elements in the stream, the result is the identity.
+// If the optional ParallelConfig is not provided, or has Serial flags, the reduction is executed serially like Reduce, and mergeAccs is not used.
+func (fin Finisher) ReduceParallel(
+	identity interface{},
+	combine func(accumulator interface{}, element interface{}) interface{},
+	mergeAccs func(accumulator1, accumulator2 interface{}) interface{},
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) interface{} {
+	if (len(pc) == 0) || (pc[0].Flags == Serial) {
+		return fin.Reduce(identity, combine, source, pc...)
+	}
+
+	var (
+		splitData = splitParallel(fin.Iter(source, pc...), pc[0].NumberOfItems, pc[0].Flags)
+		accs      = make([]interface{}, len(splitData))
+		wg        = &sync.WaitGroup{}
+	)
+
+	if len(splitData) == 0 {
+		return identity
+	}
+
+	// Reduce each chunk in a separate goroutine
+	for i, row := range splitData {
+		wg.Add(1)
+
+		go func(i int, row []interface{}) {
+			defer wg.Done()
+
+			acc := identity
+			for _, element := range row {
+				acc = combine(acc, element)
+			}
+
+			accs[i] = acc
+		}(i, row)
+	}
+
+	wg.Wait()
+
+	// Merge partial results in order
+	result := accs[0]
+	for _, acc := range accs[1:] {
+		result = mergeAccs(result, acc)
+	}
+
+	return result
+}
+
+// Shard returns n shards of the elements, where each element is placed in the shard selected by hashing keyFn(element).
+// The hash is of the Go syntax representation of the key (fmt %#v), so elements with equal keys are always placed in the same shard,
+// across calls and processes.
+// Each shard is a slice of elements in the order they were iterated, and some shards may be empty.
+// Panics with ErrInvalidShardCount if n <= 0.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before sharding.
+func (fin Finisher) Shard(
+	n int,
+	keyFn func(element interface{}) (key interface{}),
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) [][]interface{} {
+	if n <= 0 {
+		panic(ErrInvalidShardCount)
+	}
+
+	shards := make([][]interface{}, n)
+	for i := range shards {
+		shards[i] = []interface{}{}
+	}
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		var (
+			element = it.Value()
+			hash    = fnv.New64a()
+		)
+
+		fmt.Fprintf(hash, "%#v", keyFn(element))
+		shard := hash.Sum64() % uint64(n)
+		shards[shard] = append(shards[shard], element)
+	}
+
+	return shards
+}
+
+// Single returns the optional only element of applying any transforms to the stream source.
+// If there are no elements, an empty Optional is returned, and if there is more than one element, an error is returned.
+// Note that an empty Optional also means the only element is nil.
+// Unless the optional ParallelConfig is provided, no further elements are read after the second element.
+func (fin Finisher) Single(source *iter.Iter, pc ...ParallelConfig) (optional.Optional, error) {
+	it := fin.Iter(source, pc...)
+	if !it.Next() {
+		return optional.Of(), nil
+	}
+
+	val := it.Value()
+	if it.Next() {
+		return optional.Of(), errors.New(ErrMoreThanOneElement)
+	}
+
+	return optional.Of(val), nil
+}
+
+// Statistics returns the count, mean, population variance, min, and max of the elements, calculated in a single pass
+// using Welford's algorithm, which avoids the loss of precision of subtracting a squared mean from a mean of squares.
+// The slice elements must be convertible to a float64.
+// If there are no elements, all results are zero.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before the calculation.
+func (fin Finisher) Statistics(source *iter.Iter, pc ...ParallelConfig) (count int, mean, variance, min, max float64) {
+	var m2 float64
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		val := it.Float64Value()
+		count++
+
+		if count == 1 {
+			min, max = val, val
+		} else if val < min {
+			min = val
+		} else if val > max {
+			max = val
+		}
+
+		delta := val - mean
+		mean += delta / float64(count)
+		m2 += delta * (val - mean)
+	}
+
+	if count > 0 {
+		variance = m2 / float64(count)
+	}
+
+	return
+}
+
 // Sum returns an optional sum value.
 // The slice elements must be convertible to a float64.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before the calculation.
@@ -492,6 +1618,28 @@ func (fin Finisher) Sum(source *iter.Iter, pc ...ParallelConfig) optional.Option
 	return optional.Of(sum)
 }
 
+// SumAsBigFloat returns an optional sum value as a *big.Float.
+// The slice elements must be ints, uints, floats, math/big numbers, or json.Numbers.
+// The sum is accumulated exactly, and the result has enough precision to represent it, so large sums do not lose digits.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before the calculation.
+func (fin Finisher) SumAsBigFloat(source *iter.Iter, pc ...ParallelConfig) optional.Optional {
+	var (
+		sum    = new(big.Rat)
+		hasSum bool
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		sum.Add(sum, toBigRat(it.Value()))
+		hasSum = true
+	}
+
+	if !hasSum {
+		return optional.Of()
+	}
+
+	return optional.Of(new(big.Float).SetRat(sum))
+}
+
 // SumAsInt returns an optional sum value.
 // The slice elements must be convertible to an int.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before the calculation.
@@ -579,6 +1727,19 @@ func (fin Finisher) ToMapOf(
 	return m.Interface()
 }
 
+// ToSeq returns a Go range-over-func style sequence of the elements produced by applying any transforms to the source.
+// The source is not read until the sequence is called, and reading stops as soon as yield returns false.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution when the sequence is called.
+func (fin Finisher) ToSeq(source *iter.Iter, pc ...ParallelConfig) func(yield func(interface{}) bool) {
+	return func(yield func(interface{}) bool) {
+		for it := fin.Iter(source, pc...); it.Next(); {
+			if !yield(it.Value()) {
+				return
+			}
+		}
+	}
+}
+
 // ToSlice returns a slice of all elements.
 // If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before collecting.
 func (fin Finisher) ToSlice(source *iter.Iter, pc ...ParallelConfig) []interface{} {
@@ -609,6 +1770,142 @@ func (fin Finisher) ToSliceOf(elementVal interface{}, source *iter.Iter, pc ...P
 	return array.Interface()
 }
 
+// ToSliceOfLenient collects the elements into a slice of the same type as elementVal, skipping elements that cannot be converted.
+// Elements are converted with iter.Iter.ValueOfType, but unlike ToSliceOf, an element that cannot be converted does not cause a panic -
+// onError is called with the element and the iter.ConversionError describing why it cannot be converted, and the element is skipped.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before collecting.
+func (fin Finisher) ToSliceOfLenient(
+	elementVal interface{},
+	onError func(element interface{}, err error),
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) interface{} {
+	var (
+		array = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(elementVal)), 0, 0)
+		// convert returns the converted value, or recovers the ConversionError if the value cannot be converted
+		convert = func(it *iter.Iter) (value interface{}, convErr *iter.ConversionError) {
+			defer func() {
+				if r := recover(); r != nil {
+					ce, isa := r.(iter.ConversionError)
+					if !isa {
+						panic(r)
+					}
+
+					convErr = &ce
+				}
+			}()
+
+			value = it.ValueOfType(elementVal)
+			return
+		}
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		if value, convErr := convert(it); convErr != nil {
+			onError(convErr.Value, *convErr)
+		} else {
+			array = reflect.Append(array, reflect.ValueOf(value))
+		}
+	}
+
+	return array.Interface()
+}
+
+// ToSliceReversed returns a slice of all elements in reverse order, so that the last element is first.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before collecting.
+func (fin Finisher) ToSliceReversed(source *iter.Iter, pc ...ParallelConfig) []interface{} {
+	array := fin.ToSlice(source, pc...)
+
+	for i, j := 0, len(array)-1; i < j; i, j = i+1, j-1 {
+		array[i], array[j] = array[j], array[i]
+	}
+
+	return array
+}
+
+// ToSortedSliceOf collects the elements into a slice of the same type as elementVal, sorted by the provided comparator.
+// The comparator receives elements that have already been converted to the type of elementVal.
+// Panics if elements are not convertible to the type of elementVal.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before sorting.
+func (fin Finisher) ToSortedSliceOf(
+	elementVal interface{},
+	less func(element1, element2 interface{}) bool,
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) interface{} {
+	var (
+		sorted  = fin.ToSliceOf(elementVal, source, pc...)
+		rSorted = reflect.ValueOf(sorted)
+	)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return less(rSorted.Index(i).Interface(), rSorted.Index(j).Interface())
+	})
+
+	return sorted
+}
+
+// ToStructByFieldKeys returns a slice of structs, where each record of iter.KeyValue elements is decoded with MapToStruct(typ).
+// Each iter.KeyValue has a string Key that is a field name, and a Value that is the field value.
+// A record starts with the first element, and each element for which startsRecord returns true, and includes all elements up to the next record.
+// EG, if startsRecord returns true for a Key of "FirstName", then the elements FirstName:Jane, Age:56, FirstName:John, Age:65 are two records.
+// If a record contains the same field more than once, the last value is used.
+// The slice type is the same as for ToStructSlice.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before decoding.
+// Panics if typ is not zero or more pointers to a struct or a reflect.Type instance of the same.
+// Panics if the elements are not iter.KeyValue with a string Key.
+func (fin Finisher) ToStructByFieldKeys(
+	typ interface{},
+	startsRecord func(element interface{}) bool,
+	source *iter.Iter,
+	pc ...ParallelConfig,
+) interface{} {
+	var (
+		records []interface{}
+		record  map[string]interface{}
+	)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		element := it.Value()
+		if (record == nil) || startsRecord(element) {
+			record = map[string]interface{}{}
+			records = append(records, record)
+		}
+
+		kv := element.(iter.KeyValue)
+		record[kv.Key.(string)] = kv.Value
+	}
+
+	return NewFinisher().ToStructSlice(typ, iter.OfElements(records))
+}
+
+// ToStructSlice returns a slice of structs, where each map[string]interface{} element is decoded with MapToStruct(typ).
+// The slice type is a slice of the type of typ, EG if typ is a Person{}, a []Person is returned.
+// If typ is a reflect.Type, the slice type is a slice of that type.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before decoding.
+// Panics if typ is not zero or more pointers to a struct or a reflect.Type instance of the same.
+// Panics if the elements are not map[string]interface{}.
+func (fin Finisher) ToStructSlice(typ interface{}, source *iter.Iter, pc ...ParallelConfig) interface{} {
+	var (
+		mapFn      = MapToStruct(typ)
+		elementTyp reflect.Type
+	)
+
+	if refTyp, isa := typ.(reflect.Type); isa {
+		elementTyp = refTyp
+	} else {
+		elementTyp = reflect.TypeOf(typ)
+	}
+
+	array := reflect.MakeSlice(reflect.SliceOf(elementTyp), 0, 0)
+
+	for it := fin.Iter(source, pc...); it.Next(); {
+		array = reflect.Append(array, reflect.ValueOf(mapFn(it.Value())))
+	}
+
+	return array.Interface()
+}
+
 const (
 	toWriterBufSize int = 64 * 1024
 )
@@ -719,6 +2016,140 @@ func (fin Finisher) ToRuneWriter(w io.Writer, source *iter.Iter, pc ...ParallelC
 	return totalCount, nil
 }
 
+// ToLineWriter writes the source to the Writer after applying any transformations, where each element is written as a line.
+// Each element is converted to a string as for iter.StringValue, and followed by the given eol sequence.
+// If eol is empty, it defaults to "\n".
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before writing it.
+// Panics if elements are not convertible to string.
+func (fin Finisher) ToLineWriter(w io.Writer, eol string, source *iter.Iter, pc ...ParallelConfig) (int, error) {
+	if eol == "" {
+		eol = "\n"
+	}
+
+	var (
+		buf        = make([]byte, toWriterBufSize)
+		count      = 0
+		totalCount = 0
+	)
+
+	writeOp := func() (int, error) {
+		// Write buffer contents - could be a full buffer or remainder left at end
+		n, err := w.Write(buf[0:count])
+
+		// Track total number of bytes written so far - if an error occurs, n is probably < count
+		totalCount += n
+
+		// If an error occurred, return (totalCount, error)
+		if err != nil {
+			return totalCount, err
+		}
+
+		// Reset count in case there are further writes
+		count = 0
+
+		// Return success values
+		return totalCount, nil
+	}
+
+	// Read transformed data as strings to write
+	for it := fin.Iter(source, pc...); it.Next(); {
+		// Convert each string element and eol sequence to bytes and write them one at a time
+		for _, lineByte := range []byte(it.StringValue() + eol) {
+			buf[count] = lineByte
+			count++
+
+			// When the buffer is full, write it to the writer, then continue in case there is more data
+			if count == toWriterBufSize {
+				if n, err := writeOp(); err != nil {
+					return n, err
+				}
+			}
+		}
+	}
+
+	// If iter ran out with a partially filled buffer, write the remainder and return (totalCount, nil)
+	if count > 0 {
+		return writeOp()
+	}
+
+	// If iter is an exact multiple of the buffer size, return (totalCount, nil)
+	return totalCount, nil
+}
+
+// countingWriter is an io.Writer that counts the bytes written to an underlying io.Writer
+type countingWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.count += n
+	return n, err
+}
+
+// ToBase64Writer writes the source to the Writer as base64 text using the given encoding, after applying any transformations.
+// Each element is converted to a byte with ByteValue before being encoded.
+// Returns the number of encoded bytes written to w, and the first error that occurred, if any.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before writing it.
+func (fin Finisher) ToBase64Writer(encoding *base64.Encoding, w io.Writer, source *iter.Iter, pc ...ParallelConfig) (int, error) {
+	var (
+		cw      = &countingWriter{w: w}
+		encoder = base64.NewEncoder(encoding, cw)
+	)
+
+	// ToByteWriter converts each element to a byte, and writes them to the encoder
+	if _, err := fin.ToByteWriter(encoder, source, pc...); err != nil {
+		return cw.count, err
+	}
+
+	// Flush any partially encoded block and padding
+	err := encoder.Close()
+	return cw.count, err
+}
+
+// ToGzipWriter writes the source to the Writer as gzip compressed data, after applying any transformations.
+// Each element is converted to a byte with ByteValue before being compressed.
+// Returns the number of compressed bytes written to w, and the first error that occurred, if any.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before writing it.
+func (fin Finisher) ToGzipWriter(w io.Writer, source *iter.Iter, pc ...ParallelConfig) (int, error) {
+	var (
+		cw         = &countingWriter{w: w}
+		compressor = gzip.NewWriter(cw)
+	)
+
+	// ToByteWriter converts each element to a byte, and writes them to the compressor
+	if _, err := fin.ToByteWriter(compressor, source, pc...); err != nil {
+		return cw.count, err
+	}
+
+	// Flush any remaining compressed data and the gzip footer
+	err := compressor.Close()
+	return cw.count, err
+}
+
+// ToNDJSONWriter writes the source to the Writer as newline delimited JSON, after applying any transformations.
+// Each element is encoded as JSON on its own line, using json.Encoder.
+// Returns the number of bytes written to w, and the first error that occurred, if any.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before writing it.
+func (fin Finisher) ToNDJSONWriter(w io.Writer, source *iter.Iter, pc ...ParallelConfig) (int, error) {
+	var (
+		cw      = &countingWriter{w: w}
+		buf     = bufio.NewWriterSize(cw, toWriterBufSize)
+		encoder = json.NewEncoder(buf)
+	)
+
+	// Encode appends a newline after each element
+	for it := fin.Iter(source, pc...); it.Next(); {
+		if err := encoder.Encode(it.Value()); err != nil {
+			return cw.count, err
+		}
+	}
+
+	err := buf.Flush()
+	return cw.count, err
+}
+
 //
 // ==== Continuation
 //
@@ -732,3 +2163,10 @@ func (fin Finisher) AndStream(pc ...ParallelConfig) Stream {
 		},
 	)
 }
+
+// AndThenReader returns an io.Reader that reads the bytes produced by applying the transforms to the source.
+// Each element is converted to a byte with ByteValue as it is read, so the source is only iterated as the Reader is read.
+// If the optional ParallelConfig is provided, the transformed data set is collected via parallel execution before returning the Reader.
+func (fin Finisher) AndThenReader(source *iter.Iter, pc ...ParallelConfig) io.Reader {
+	return iterToByteReader(fin.Iter(source, pc...))
+}
@@ -4,11 +4,22 @@ package stream
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
 	"math/big"
 	"reflect"
+	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/bantling/gomicro/iter"
 )
@@ -21,6 +32,19 @@ const (
 	ErrNotAnArrayOrSlice   = "The elements must be arrays or slices"
 	ErrInvalidBigInt       = "A number couild not be converted to a math/big.Int"
 	ErrInvalidBigFloat     = "A number couild not be converted to a math/big.Float"
+	ErrInvalidBigRat       = "The elements must be finite numbers, math/big numbers, or json.Numbers"
+	ErrInvalidMaxKeys      = "maxKeys must be > 0"
+	ErrNegativeSkip        = "Skip count must be >= 0"
+	ErrInvalidNDJSONLine   = "Line %d is not a valid JSON value: %s"
+	ErrInvalidEveryNth     = "n must be > 0"
+	ErrMissingJSONKey      = "JSON object %d is missing required key %q"
+	ErrInvalidDuration     = "%q is not a valid duration: %s"
+	ErrInvalidShardCount   = "Shard count must be > 0"
+	ErrInvalidAttempts     = "attempts must be > 0"
+	ErrInvalidConcurrency  = "concurrency must be > 0"
+	ErrNegativeLimitBytes  = "LimitBytes max must be >= 0"
+	ErrInvalidJSONPath     = "%q is not a valid JSON path"
+	ErrMoreThanOneElement  = "There is more than one element"
 )
 
 // ==== Compose
@@ -45,7 +69,8 @@ func composeGenerators(f1, f2 func() func(*iter.Iter) *iter.Iter) func() func(*i
 
 // ==== Parallel
 
-// ParallelFlags is a pair of flags indicating whether to interpret the number as the number of goroutines or the number of items each goroutine processes
+// ParallelFlags is a set of flags indicating whether to interpret the number as the number of goroutines or the number of items each goroutine processes,
+// or to ignore the number and use one goroutine per CPU
 type ParallelFlags uint
 
 const (
@@ -53,20 +78,123 @@ const (
 	NumberOfGoroutines ParallelFlags = iota
 	// NumberOfItemsPerGoroutine indicates the number of items each goroutine processes
 	NumberOfItemsPerGoroutine
+	// AutoGoroutines ignores the number, and indicates the number of goroutines is runtime.NumCPU().
+	// As with NumberOfGoroutines, if there are fewer items than CPUs, each item is processed by a separate goroutine.
+	AutoGoroutines
+	// Serial ignores the number, and indicates the transforms are executed serially as if no ParallelConfig were provided.
+	// This allows a Progress callback to be used without parallel execution.
+	Serial
 )
 
 const (
 	// DefaultNumberOfParallelItems is the default number of items when executing transforms in parallel
 	DefaultNumberOfParallelItems uint = 50
+
+	// DefaultProgressInterval is the default number of elements between calls to a Progress callback
+	DefaultProgressInterval uint = 1000
 )
 
 // ParallelConfig contains a configuration for parallel execution.
-// NumberOfItems defaults to DefaultNumberOfParallelItems.
+// NumberOfItems defaults to DefaultNumberOfParallelItems, and is ignored if Flags is AutoGoroutines or Serial.
 // Flags defaults to NumberOfGoroutines.
+//
+// Progress is an optional callback to report progress of long running terminals.
+// It is called with the number of source elements processed so far, after every ProgressInterval elements.
+// When all elements have been processed, it is called once more with the final count, unless the final count is a multiple of ProgressInterval.
+// ProgressInterval defaults to DefaultProgressInterval.
+// Elements are counted as they are processed, so Progress is called during parallel execution, from the goroutines that process the elements.
+// It must be safe for concurrent use, and as goroutines run independently, the counts may be reported out of order.
+// The final count is always reported last, before the terminal reads any elements.
+// If Flags is Serial, the transformed elements are counted as the terminal reads them, and Progress is called from the goroutine
+// that executes the terminal. The final count is reported when the transformed elements are exhausted, so it is not reported
+// by terminals that stop reading early, such as First.
+//
 // The zero value is ready to use.
 type ParallelConfig struct {
-	NumberOfItems uint
-	Flags         ParallelFlags
+	NumberOfItems    uint
+	Flags            ParallelFlags
+	Progress         func(count int)
+	ProgressInterval uint
+}
+
+// progressCounter counts processed elements with an atomic counter, calling a progress callback as described by ParallelConfig.
+// A nil *progressCounter does not count anything, so that counting is free when there is no callback.
+type progressCounter struct {
+	count    int64
+	interval int64
+	progress func(count int)
+}
+
+// newProgressCounter returns a progressCounter for the given callback, or nil if the callback is nil.
+// If interval is 0, the default value is DefaultProgressInterval.
+func newProgressCounter(progress func(count int), interval uint) *progressCounter {
+	if progress == nil {
+		return nil
+	}
+
+	n := DefaultProgressInterval
+	if interval > 0 {
+		n = interval
+	}
+
+	return &progressCounter{interval: int64(n), progress: progress}
+}
+
+// countIter wraps an Iter so that each element read from it is counted
+func (p *progressCounter) countIter(it *iter.Iter) *iter.Iter {
+	if p == nil {
+		return it
+	}
+
+	return iter.New(func() (interface{}, bool) {
+		if !it.Next() {
+			return nil, false
+		}
+
+		value := it.Value()
+		if count := atomic.AddInt64(&p.count, 1); count%p.interval == 0 {
+			p.progress(int(count))
+		}
+
+		return value, true
+	})
+}
+
+// done reports the final count, if it has not already been reported
+func (p *progressCounter) done() {
+	if p == nil {
+		return
+	}
+
+	if count := atomic.LoadInt64(&p.count); count%p.interval != 0 {
+		p.progress(int(count))
+	}
+}
+
+var (
+	// numCPU returns the number of CPUs for AutoGoroutines, tests may replace it
+	numCPU = runtime.NumCPU
+)
+
+// splitParallel splits the source into rows of contiguous elements, where each row is processed by a separate goroutine.
+// If numItems is 0, the default value is DefaultNumberOfParallelItems.
+func splitParallel(source *iter.Iter, numItems uint, flag ParallelFlags) [][]interface{} {
+	n := DefaultNumberOfParallelItems
+	if numItems > 0 {
+		n = numItems
+	}
+
+	switch flag {
+	case NumberOfGoroutines:
+		// numItems = desired number of rows; number of colums to be determined
+		return source.SplitIntoColumns(n)
+	case NumberOfItemsPerGoroutine:
+		// numItems = desired number of columns; number of rows to be determined
+		return source.SplitIntoRows(n)
+	default:
+		// number of CPUs = desired number of rows; number of columns to be determined
+		return source.SplitIntoColumns(uint(numCPU()))
+	}
 }
 
 // doParallel does the grunt work of parallel processing, returning a slice of results.
@@ -77,25 +205,14 @@ func doParallel(
 	generator func() func(*iter.Iter) *iter.Iter,
 	numItems uint,
 	flag ParallelFlags,
+	progress *progressCounter,
 ) []interface{} {
-	n := DefaultNumberOfParallelItems
-	if numItems > 0 {
-		n = numItems
-	}
-
 	var flatData []interface{}
 	if transform == nil {
 		// If the transform is nil, there is no transform, just use source values as is
-		flatData = source.ToSlice()
+		flatData = progress.countIter(source).ToSlice()
 	} else {
-		var splitData [][]interface{}
-		if flag == NumberOfGoroutines {
-			// numItems = desired number of rows; number of colums to be determined
-			splitData = source.SplitIntoColumns(n)
-		} else {
-			// numItems = desired number of columns; number of rows to be determined
-			splitData = source.SplitIntoRows(n)
-		}
+		splitData := splitParallel(source, numItems, flag)
 
 		// Execute goroutines, one per row of splitData.
 		// Each goroutine applies the queued operations to each item in its row.
@@ -107,7 +224,7 @@ func doParallel(
 			go func(i int, row []interface{}) {
 				defer wg.Done()
 
-				splitData[i] = transform(iter.OfElements(row)).ToSlice()
+				splitData[i] = transform(progress.countIter(iter.OfElements(row))).ToSlice()
 			}(i, row)
 		}
 
@@ -123,10 +240,95 @@ func doParallel(
 		flatData = generator()(iter.Of(flatData...)).ToSlice()
 	}
 
+	// Report final count after all processing is complete
+	progress.done()
+
 	// Return transformed rows
 	return flatData
 }
 
+// ==== Readers
+
+// iterToByteReader adapts an Iter into an io.Reader, where each element is converted to a byte with ByteValue as it is read.
+// Panics if an element is not convertible to a byte.
+func iterToByteReader(it *iter.Iter) io.Reader {
+	return iter.New(func() (interface{}, bool) {
+		if !it.Next() {
+			return nil, false
+		}
+
+		return it.ByteValue(), true
+	}).ToReader()
+}
+
+// readerToByteIter adapts an io.Reader into an Iter of bytes, reading the bytes in blocks.
+// Unlike iter.OfReader, bytes returned by the reader along with an error are iterated before the error is handled.
+// Panics if the reader returns an error other than io.EOF.
+func readerToByteIter(src io.Reader) *iter.Iter {
+	var (
+		buf  = make([]byte, toWriterBufSize)
+		data []byte
+		err  error
+	)
+
+	return iter.New(func() (interface{}, bool) {
+		// Read more bytes if we need to
+		for len(data) == 0 {
+			if err != nil {
+				if err != io.EOF {
+					panic(err)
+				}
+
+				return nil, false
+			}
+
+			var n int
+			n, err = src.Read(buf)
+			data = buf[:n]
+		}
+
+		b := data[0]
+		data = data[1:]
+		return b, true
+	})
+}
+
+// ==== Big
+
+// toBigRat converts a value into a new math/big.Rat that is exactly equal to it.
+// The value may be any int, uint, or float type, a *big.Int, *big.Float, or *big.Rat, or a json.Number.
+// Panics with ErrInvalidBigRat if the value is any other type, an infinite or NaN float, or a json.Number that is not a valid number.
+func toBigRat(value interface{}) *big.Rat {
+	switch v := value.(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(v)
+	case *big.Float:
+		if !v.IsInf() {
+			r, _ := v.Rat(nil)
+			return r
+		}
+	case *big.Rat:
+		return new(big.Rat).Set(v)
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(v.String()); ok {
+			return r
+		}
+	default:
+		switch rv := reflect.ValueOf(value); rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return new(big.Rat).SetInt64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint()))
+		case reflect.Float32, reflect.Float64:
+			if r := new(big.Rat).SetFloat64(rv.Float()); r != nil {
+				return r
+			}
+		}
+	}
+
+	panic(ErrInvalidBigRat)
+}
+
 // ==== Transform
 
 // JSONDocType describes what kind of JSON documents to allow - arrays or objects, only arrays, or only objects
@@ -435,3 +637,505 @@ func FromArraySlice() func(*iter.Iter) *iter.Iter {
 		})
 	}
 }
+
+// JSONArrayElements is a Transform function that combines ToJSON and FromArraySlice,
+// so that each element of each JSON array in the source bytes is a single element in the output.
+// The DocType of the optional config is ignored, the source may only contain arrays.
+// The NumType of the optional config is respected, the default is to leave numbers as json.Number.
+//
+// Panics if the elements are not bytes.
+// Panics if the elements do not contain valid JSON arrays.
+func JSONArrayElements(config ...JSONConfig) func() func(*iter.Iter) *iter.Iter {
+	var cfg JSONConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	cfg.DocType = JSONArray
+
+	return composeGenerators(ToJSON(cfg), FromArraySlice)
+}
+
+// GroupLinesByHeader is a Transform function that groups consecutive lines under a header line.
+// A new group starts whenever a line matches headerPattern, and all following lines that do not match are accumulated into it.
+// Each group is iterated as a []string, where the first string is the header line.
+// Any lines before the first header form their own group, which has no header.
+//
+// Panics if the elements are not strings.
+func GroupLinesByHeader(headerPattern *regexp.Regexp) func() func(*iter.Iter) *iter.Iter {
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			var (
+				header    string
+				hasHeader bool
+			)
+
+			return iter.New(func() (interface{}, bool) {
+				var group []string
+
+				// Start with header read by the last call, if any
+				if hasHeader {
+					group = append(group, header)
+					hasHeader = false
+				}
+
+				for it.Next() {
+					line := it.StringValue()
+					if headerPattern.MatchString(line) && (group != nil) {
+						// Keep header to start the next group
+						header, hasHeader = line, true
+						return group, true
+					}
+
+					group = append(group, line)
+				}
+
+				if group == nil {
+					return nil, false
+				}
+
+				return group, true
+			})
+		}
+	}
+}
+
+// Base64Decode is a Transform function that decodes the source bytes or runes of base64 text using the given encoding.
+// Newlines in the source are ignored, and any padding is handled as required by the encoding.
+// Each decoded byte is a single element in the output.
+//
+// Panics if the elements are not convertible to bytes.
+// Panics if the source is not valid base64 text for the encoding.
+func Base64Decode(encoding *base64.Encoding) func() func(*iter.Iter) *iter.Iter {
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			return readerToByteIter(base64.NewDecoder(encoding, iterToByteReader(it)))
+		}
+	}
+}
+
+// CSVConfig contains the parameters for CSV parsing.
+// The zero value uses a comma separator, does not allow comments, and requires quotes to be used correctly.
+type CSVConfig struct {
+	// Comma is the field separator, the default is ','
+	Comma rune
+	// Comment is the character that starts a comment line, the default is no comments
+	Comment rune
+	// LazyQuotes allows quotes to appear in unquoted fields, and non-doubled quotes to appear in quoted fields
+	LazyQuotes bool
+	// TrimLeadingSpace ignores leading white space in each field
+	TrimLeadingSpace bool
+}
+
+// FromCSVWithHeader is a Transform function that maps the source bytes of CSV text into a map[string]interface{} per record.
+// The first record is the header, which is not iterated, and provides the map keys for the remaining records.
+// Each field value is a string, keyed by the header name in the same column.
+//
+// If the header has duplicate names, the field of the later column wins.
+// If a record has fewer fields than the header, the header names with no field are not in the map.
+// If a record has more fields than the header, the extra fields are ignored.
+//
+// Panics if the elements are not convertible to bytes.
+// Panics if the source is not valid CSV text.
+func FromCSVWithHeader(config ...CSVConfig) func() func(*iter.Iter) *iter.Iter {
+	var cfg CSVConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			var (
+				reader = csv.NewReader(iterToByteReader(it))
+				header []string
+			)
+
+			// Records may have any number of fields
+			reader.FieldsPerRecord = -1
+			if cfg.Comma != 0 {
+				reader.Comma = cfg.Comma
+			}
+			reader.Comment = cfg.Comment
+			reader.LazyQuotes = cfg.LazyQuotes
+			reader.TrimLeadingSpace = cfg.TrimLeadingSpace
+
+			readRecord := func() ([]string, bool) {
+				record, err := reader.Read()
+				if err != nil {
+					if err != io.EOF {
+						panic(err)
+					}
+
+					return nil, false
+				}
+
+				return record, true
+			}
+
+			return iter.New(func() (interface{}, bool) {
+				// Read header on first call
+				if header == nil {
+					var haveIt bool
+					if header, haveIt = readRecord(); !haveIt {
+						return nil, false
+					}
+				}
+
+				record, haveIt := readRecord()
+				if !haveIt {
+					return nil, false
+				}
+
+				row := map[string]interface{}{}
+				for i, name := range header {
+					if i < len(record) {
+						row[name] = record[i]
+					}
+				}
+
+				return row, true
+			})
+		}
+	}
+}
+
+// GunzipBytes is a Transform function that decompresses the source bytes of gzip data.
+// Each decompressed byte is a single element in the output.
+// An empty source produces no elements.
+//
+// Panics if the elements are not convertible to bytes.
+// Panics if the source is not valid gzip data.
+func GunzipBytes() func() func(*iter.Iter) *iter.Iter {
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			var decompressed *iter.Iter
+
+			return iter.New(func() (interface{}, bool) {
+				// Create the gzip reader on first call, as it reads the gzip header immediately
+				if decompressed == nil {
+					reader, err := gzip.NewReader(iterToByteReader(it))
+					switch {
+					case err == io.EOF:
+						// Empty source
+						decompressed = iter.Of()
+					case err != nil:
+						panic(err)
+					default:
+						decompressed = readerToByteIter(reader)
+					}
+				}
+
+				if !decompressed.Next() {
+					return nil, false
+				}
+
+				return decompressed.Value(), true
+			})
+		}
+	}
+}
+
+// FromNDJSON is a Transform function that maps each line of newline delimited JSON from the source bytes into a JSON value.
+// Each line may contain any JSON value - an array, object, string, number, boolean, or null - which is a single element in the output.
+// Numbers are converted to the Go type indicated by numType, as described by JSONConfig.
+// Blank lines are skipped.
+//
+// Panics if the elements are not convertible to bytes.
+// Panics with ErrInvalidNDJSONLine, formatted with the line number and error, if a line does not contain exactly one valid JSON value.
+func FromNDJSON(numType JSONNumberType) func() func(*iter.Iter) *iter.Iter {
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			var (
+				lines  = iter.OfReaderLines(iterToByteReader(it))
+				lineNo = 0
+			)
+
+			return iter.New(func() (interface{}, bool) {
+				for lines.Next() {
+					lineNo++
+
+					line := lines.StringValue()
+					if strings.TrimSpace(line) == "" {
+						continue
+					}
+
+					var (
+						doc     interface{}
+						decoder = json.NewDecoder(strings.NewReader(line))
+					)
+					// Decode numbers as json.Number
+					decoder.UseNumber()
+
+					if err := decoder.Decode(&doc); err != nil {
+						panic(fmt.Sprintf(ErrInvalidNDJSONLine, lineNo, err))
+					}
+
+					// The line must not contain anything after the value
+					if _, err := decoder.Token(); err != io.EOF {
+						panic(fmt.Sprintf(ErrInvalidNDJSONLine, lineNo, "unexpected data after value"))
+					}
+
+					// If the desired numeric type is not json.Number, then convert all json.Number to the requested type.
+					// The value is wrapped in an array, as it may be a number, and only arrays and objects are converted.
+					if numType != JSONNumAsNumber {
+						doc = JSONDocumentNumberConversion([]interface{}{doc}, JSONNumberConversion(numType)).([]interface{})[0]
+					}
+
+					return doc, true
+				}
+
+				return nil, false
+			})
+		}
+	}
+}
+
+// FromKeyValueLines is a Transform function that maps each source line into an iter.KeyValue,
+// by splitting it on the first occurrence of sep into a key and value, which are both trimmed strings.
+// If a line does not contain sep, the whole trimmed line is the key, and the value is an empty string.
+// Blank lines and lines whose first non-blank character is '#' are skipped.
+//
+// Panics if the elements are not strings.
+func FromKeyValueLines(sep string) func() func(*iter.Iter) *iter.Iter {
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			return iter.New(func() (interface{}, bool) {
+				for it.Next() {
+					line := strings.TrimSpace(it.StringValue())
+					if (line == "") || strings.HasPrefix(line, "#") {
+						continue
+					}
+
+					var (
+						parts = strings.SplitN(line, sep, 2)
+						kv    = iter.KeyValue{Key: strings.TrimSpace(parts[0]), Value: ""}
+					)
+
+					if len(parts) == 2 {
+						kv.Value = strings.TrimSpace(parts[1])
+					}
+
+					return kv, true
+				}
+
+				return nil, false
+			})
+		}
+	}
+}
+
+// NormalizeEOL is a Transform function that collapses each CR, LF, and CRLF sequence of the source runes into the runes of to, EG "\n".
+// As with iter.RunePositionIter, a CR is checked for a following LF by reading the next rune, and unreading it if it is not an LF.
+// All other runes are iterated as is.
+//
+// Panics if the elements are not convertible to runes.
+func NormalizeEOL(to string) func() func(*iter.Iter) *iter.Iter {
+	eol := []rune(to)
+
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			// Runes of an EOL that have not been iterated yet
+			var pending []rune
+
+			return iter.New(func() (interface{}, bool) {
+				for len(pending) == 0 {
+					if !it.Next() {
+						return nil, false
+					}
+
+					switch r := it.RuneValue(); r {
+					case '\r':
+						// If it is a CRLF, consume the LF
+						if it.Next() {
+							if peek := it.RuneValue(); peek != '\n' {
+								// Just a CR, unread this second rune
+								it.Unread(peek)
+							}
+						}
+
+						pending = eol
+					case '\n':
+						pending = eol
+					default:
+						return r, true
+					}
+				}
+
+				r := pending[0]
+				pending = pending[1:]
+				return r, true
+			})
+		}
+	}
+}
+
+// ValidateJSONObject is a Transform function that passes through each JSON object that contains all of the required keys.
+// The objects are typically the result of ToJSON or FromNDJSON.
+//
+// Panics with ErrMissingJSONKey, formatted with the object number (starting at 1) and the first missing key, if an object is missing a required key.
+// Panics with ErrInvalidJSONObject if an element is not a map[string]interface{}.
+func ValidateJSONObject(required []string) func() func(*iter.Iter) *iter.Iter {
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			objectNo := 0
+
+			return iter.New(func() (interface{}, bool) {
+				if !it.Next() {
+					return nil, false
+				}
+
+				object, isa := it.Value().(map[string]interface{})
+				if !isa {
+					panic(ErrInvalidJSONObject)
+				}
+
+				objectNo++
+				for _, key := range required {
+					if _, haveIt := object[key]; !haveIt {
+						panic(fmt.Sprintf(ErrMissingJSONKey, objectNo, key))
+					}
+				}
+
+				return object, true
+			})
+		}
+	}
+}
+
+// ParseDurations is a Transform function that maps each source string into a time.Duration using time.ParseDuration,
+// EG "1h30m" is mapped to 90 minutes.
+//
+// Panics with ErrInvalidDuration, formatted with the string and error, if a string is not a valid duration.
+// Panics if the elements are not strings.
+func ParseDurations() func() func(*iter.Iter) *iter.Iter {
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			return iter.New(func() (interface{}, bool) {
+				if !it.Next() {
+					return nil, false
+				}
+
+				str := it.StringValue()
+				d, err := time.ParseDuration(str)
+				if err != nil {
+					panic(fmt.Sprintf(ErrInvalidDuration, str, err))
+				}
+
+				return d, true
+			})
+		}
+	}
+}
+
+// parseJSONPath parses a dotted path with optional [n] array indexes into a series of steps,
+// where each step is a string key of an object or an int index of an array.
+// EG, "a.b[1][2].c" is parsed into "a", "b", 1, 2, "c".
+// Panics with ErrInvalidJSONPath if the path is empty, contains an empty key, or an index is not a non-negative integer in brackets.
+func parseJSONPath(path string) []interface{} {
+	var steps []interface{}
+
+	for i, part := range strings.Split(path, ".") {
+		// Key is everything up to the first index
+		key := part
+		if j := strings.IndexByte(part, '['); j >= 0 {
+			key, part = part[:j], part[j:]
+		} else {
+			part = ""
+		}
+
+		// Only the first part can be just indexes with no key
+		switch {
+		case key != "":
+			steps = append(steps, key)
+		case (i > 0) || (part == ""):
+			panic(fmt.Sprintf(ErrInvalidJSONPath, path))
+		}
+
+		// Indexes
+		for part != "" {
+			end := strings.IndexByte(part, ']')
+			if (part[0] != '[') || (end < 0) {
+				panic(fmt.Sprintf(ErrInvalidJSONPath, path))
+			}
+
+			index, err := strconv.Atoi(part[1:end])
+			if (err != nil) || (index < 0) {
+				panic(fmt.Sprintf(ErrInvalidJSONPath, path))
+			}
+
+			steps = append(steps, index)
+			part = part[end+1:]
+		}
+	}
+
+	return steps
+}
+
+// SelectJSONPath is a Transform function that maps each JSON document into the value at the given path, or nil if there is no such value.
+// The path is a dotted series of object keys, where any key may be followed by one or more [n] array indexes,
+// EG "address.city", "phones[0]", or "matrix[1][2].value". A path may also begin with an index, EG "[0].name".
+// The documents are typically the result of ToJSON or FromNDJSON, where objects are map[string]interface{} and arrays are []interface{}.
+// A path is absent if a key does not exist, an index is out of range, or the value is not an object or array as required by the path.
+//
+// Panics with ErrInvalidJSONPath if the path is not valid.
+func SelectJSONPath(path string) func() func(*iter.Iter) *iter.Iter {
+	steps := parseJSONPath(path)
+
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			return iter.New(func() (interface{}, bool) {
+				if !it.Next() {
+					return nil, false
+				}
+
+				value := it.Value()
+				for _, step := range steps {
+					switch s := step.(type) {
+					case string:
+						object, _ := value.(map[string]interface{})
+						value = object[s]
+					case int:
+						array, _ := value.([]interface{})
+						if s >= len(array) {
+							value = nil
+						} else {
+							value = array[s]
+						}
+					}
+
+					if value == nil {
+						break
+					}
+				}
+
+				return value, true
+			})
+		}
+	}
+}
+
+// HexDecode is a Transform function that decodes the source bytes or runes of hex digits, where each pair of digits is one byte.
+// Both upper and lower case digits are accepted, and whitespace in the source is ignored.
+// Each decoded byte is a single element in the output.
+//
+// Panics if the elements are not convertible to bytes.
+// Panics with a hex.InvalidByteError if the source contains a character that is not a hex digit or whitespace.
+// Panics with io.ErrUnexpectedEOF if the source contains an odd number of hex digits.
+func HexDecode() func() func(*iter.Iter) *iter.Iter {
+	return func() func(*iter.Iter) *iter.Iter {
+		return func(it *iter.Iter) *iter.Iter {
+			digits := iter.New(func() (interface{}, bool) {
+				for it.Next() {
+					switch b := it.ByteValue(); b {
+					case ' ', '\t', '\n', '\v', '\f', '\r':
+					default:
+						return b, true
+					}
+				}
+
+				return nil, false
+			})
+
+			return readerToByteIter(hex.NewDecoder(iterToByteReader(digits)))
+		}
+	}
+}
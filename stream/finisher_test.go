@@ -4,8 +4,14 @@ package stream
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"math"
+	"math/big"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -107,6 +113,33 @@ func TestFinisherTransform(t *testing.T) {
 	)
 }
 
+func TestFinisherCoalesceRuns(t *testing.T) {
+	type interval struct {
+		start, end int
+	}
+
+	var (
+		canMerge = func(prev, cur interface{}) bool { return cur.(interval).start <= prev.(interval).end }
+		merge    = func(prev, cur interface{}) interface{} {
+			p, c := prev.(interval), cur.(interval)
+			if c.end > p.end {
+				p.end = c.end
+			}
+
+			return p
+		}
+		f = NewFinisher().CoalesceRuns(canMerge, merge)
+	)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{interval{1, 2}}, f.ToSlice(iter.Of(interval{1, 2})))
+	assert.Equal(
+		t,
+		[]interface{}{interval{1, 5}, interval{6, 7}, interval{8, 12}},
+		f.ToSlice(iter.Of(interval{1, 3}, interval{2, 5}, interval{3, 4}, interval{6, 7}, interval{8, 10}, interval{10, 12})),
+	)
+}
+
 func TestFinisherDistinct(t *testing.T) {
 	f := NewFinisher().Distinct()
 	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
@@ -115,6 +148,68 @@ func TestFinisherDistinct(t *testing.T) {
 	assert.Equal(t, []interface{}{1, 2, 3}, f.Iter(iter.Of(1, 2, 2, 1, 3)).ToSlice())
 }
 
+func TestFinisherDistinctBounded(t *testing.T) {
+	f := NewFinisher().DistinctBounded(2)
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+
+	// Within the window, duplicates are removed
+	assert.Equal(t, []interface{}{1, 2}, f.ToSlice(iter.Of(1, 2, 1, 2, 2, 1)))
+
+	// Exceeding the window allows an old value to reappear: 3 evicts 2, since 1 was used more recently
+	assert.Equal(t, []interface{}{1, 2, 3, 2, 1}, f.ToSlice(iter.Of(1, 2, 1, 3, 2, 1)))
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrInvalidMaxKeys, recover())
+		}()
+
+		NewFinisher().DistinctBounded(0)
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestFinisherDistinctFunc(t *testing.T) {
+	f := NewFinisher().DistinctFunc(func(element1, element2 interface{}) bool { return reflect.DeepEqual(element1, element2) })
+	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{[]int{1}}, f.Iter(iter.Of([]int{1})).ToSlice())
+	assert.Equal(
+		t,
+		[]interface{}{[]int{1, 2}, []int{3}, []int{}},
+		f.Iter(iter.Of([]int{1, 2}, []int{3}, []int{1, 2}, []int{}, []int{3}, []int{})).ToSlice(),
+	)
+}
+
+func TestFinisherDistinctLast(t *testing.T) {
+	f := NewFinisher().DistinctLast()
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{1}, f.ToSlice(iter.Of(1)))
+
+	// Each distinct element is at the position of its last occurrence
+	assert.Equal(t, []interface{}{1, 3, 2}, f.ToSlice(iter.Of(1, 2, 1, 3, 2)))
+	assert.Equal(t, []interface{}{"b", "a"}, f.ToSlice(iter.Of("a", "b", "a", "b", "a")))
+}
+
+func TestFinisherDistinctUntilChangedBy(t *testing.T) {
+	type event struct {
+		key  string
+		data []int
+	}
+
+	var (
+		keyFn = func(element interface{}) interface{} { return element.(event).key }
+		f     = NewFinisher().DistinctUntilChangedBy(keyFn)
+		a1    = event{"A", []int{1}}
+		a2    = event{"A", []int{2}}
+		b1    = event{"B", []int{3}}
+		b2    = event{"B", []int{4}}
+		a3    = event{"A", []int{5}}
+	)
+
+	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{a1}, f.Iter(iter.Of(a1)).ToSlice())
+	assert.Equal(t, []interface{}{a1, b1, a3}, f.Iter(iter.Of(a1, a2, b1, b2, a3)).ToSlice())
+}
+
 func TestFinisherDuplicate(t *testing.T) {
 	f := NewFinisher().Duplicate()
 	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
@@ -123,6 +218,37 @@ func TestFinisherDuplicate(t *testing.T) {
 	assert.Equal(t, []interface{}{2, 1}, f.Iter(iter.Of(1, 2, 2, 1, 3)).ToSlice())
 }
 
+func TestFinisherDuplicateFunc(t *testing.T) {
+	f := NewFinisher().DuplicateFunc(func(element1, element2 interface{}) bool { return reflect.DeepEqual(element1, element2) })
+	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{}, f.Iter(iter.Of([]int{1})).ToSlice())
+	assert.Equal(
+		t,
+		[]interface{}{[]int{1, 2}, []int{}, []int{3}},
+		f.Iter(iter.Of([]int{1, 2}, []int{3}, []int{1, 2}, []int{}, []int{}, []int{3})).ToSlice(),
+	)
+}
+
+func TestFinisherEnumerateByGroup(t *testing.T) {
+	var (
+		keyFn = func(element interface{}) interface{} { return element.(string)[0:1] }
+		f     = NewFinisher().EnumerateByGroup(keyFn)
+	)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{iter.KeyValue{Key: 0, Value: "A1"}}, f.ToSlice(iter.Of("A1")))
+	assert.Equal(
+		t,
+		[]interface{}{
+			iter.KeyValue{Key: 0, Value: "A1"},
+			iter.KeyValue{Key: 1, Value: "A2"},
+			iter.KeyValue{Key: 0, Value: "B1"},
+			iter.KeyValue{Key: 0, Value: "A3"},
+		},
+		f.ToSlice(iter.Of("A1", "A2", "B1", "A3")),
+	)
+}
+
 func TestFinisherFilter(t *testing.T) {
 	f := NewFinisher().Filter(func() func(element interface{}) bool {
 		return func(element interface{}) bool {
@@ -149,6 +275,155 @@ func TestFinisherLimit(t *testing.T) {
 	assert.Equal(t, []interface{}{1, 2}, f.Iter(iter.Of(1, 2, 3)).ToSlice())
 }
 
+func TestFinisherLimitBytes(t *testing.T) {
+	f := NewFinisher().LimitBytes(3)
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{byte('a'), byte('b')}, f.ToSlice(iter.OfReader(strings.NewReader("ab"))))
+
+	// Truncated at exactly max bytes, leaving the rest unread
+	src := iter.OfReader(strings.NewReader("abcdef"))
+	assert.Equal(t, []interface{}{byte('a'), byte('b'), byte('c')}, f.ToSlice(src))
+	assert.Equal(t, []interface{}{byte('d'), byte('e'), byte('f')}, src.ToSlice())
+
+	// Elements are converted to bytes
+	assert.Equal(t, []interface{}{byte(1), byte(2)}, NewFinisher().LimitBytes(2).ToSlice(iter.Of(1, 2, 3)))
+	assert.Equal(t, []interface{}{}, NewFinisher().LimitBytes(0).ToSlice(iter.Of(1)))
+
+	// The limit applies to all elements, not each chunk of a parallel execution
+	var (
+		data = iter.OfElements([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+		pc   = ParallelConfig{NumberOfItems: 3, Flags: NumberOfItemsPerGoroutine}
+	)
+	f = New().Map(func(element interface{}) interface{} { return element }).AndFinish().LimitBytes(2)
+	assert.Equal(t, []interface{}{byte(0), byte(1)}, f.ToSlice(data, pc))
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrNegativeLimitBytes, recover())
+		}()
+
+		NewFinisher().LimitBytes(-1)
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestFinisherMapEveryNth(t *testing.T) {
+	fn := func(element interface{}) interface{} {
+		return element.(int) * 2
+	}
+
+	f := NewFinisher().MapEveryNth(2, fn)
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{1, 4, 3, 8, 5}, f.ToSlice(iter.Of(1, 2, 3, 4, 5)))
+
+	f = NewFinisher().MapEveryNth(1, fn)
+	assert.Equal(t, []interface{}{2, 4, 6}, f.ToSlice(iter.Of(1, 2, 3)))
+
+	// Elements are counted across chunks of a parallel execution
+	f = New().
+		Map(func(element interface{}) interface{} { return element }).
+		AndFinish().
+		MapEveryNth(2, func(element interface{}) interface{} { return 100 })
+	assert.Equal(
+		t,
+		[]interface{}{0, 100, 2, 100, 4, 100, 6, 100, 8, 100},
+		f.ToSlice(iter.Of(0, 1, 2, 3, 4, 5, 6, 7, 8, 9), ParallelConfig{NumberOfItems: 3, Flags: NumberOfItemsPerGoroutine}),
+	)
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrInvalidEveryNth, recover())
+		}()
+
+		NewFinisher().MapEveryNth(0, fn)
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestFinisherMapStateful(t *testing.T) {
+	f := NewFinisher().MapStateful(
+		0,
+		func(state, element interface{}) (interface{}, interface{}) {
+			total := state.(int) + element.(int)
+			return total, fmt.Sprintf("%d:%d", element, total)
+		},
+	)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{"1:1", "2:3", "3:6"}, f.ToSlice(iter.Of(1, 2, 3)))
+
+	// State is reset for each terminal call
+	assert.Equal(t, []interface{}{"4:4", "5:9"}, f.ToSlice(iter.Of(4, 5)))
+}
+
+func TestFinisherMergeAdjacentByKey(t *testing.T) {
+	var (
+		add = func(value1, value2 interface{}) interface{} { return value1.(int) + value2.(int) }
+		f   = NewFinisher().MergeAdjacentByKey(add)
+	)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(
+		t,
+		[]interface{}{iter.KeyValue{Key: "a", Value: 3}, iter.KeyValue{Key: "b", Value: 3}},
+		f.ToSlice(iter.Of(iter.KeyValue{Key: "a", Value: 1}, iter.KeyValue{Key: "a", Value: 2}, iter.KeyValue{Key: "b", Value: 3})),
+	)
+
+	// Only adjacent keys are merged
+	assert.Equal(
+		t,
+		[]interface{}{iter.KeyValue{Key: "a", Value: 1}, iter.KeyValue{Key: "b", Value: 5}, iter.KeyValue{Key: "a", Value: 4}},
+		f.ToSlice(iter.Of(
+			iter.KeyValue{Key: "a", Value: 1},
+			iter.KeyValue{Key: "b", Value: 2},
+			iter.KeyValue{Key: "b", Value: 3},
+			iter.KeyValue{Key: "a", Value: 4},
+		)),
+	)
+}
+
+func TestFinisherPairwise(t *testing.T) {
+	f := NewFinisher().Pairwise()
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of(1)))
+	assert.Equal(t, []interface{}{iter.KeyValue{Key: 1, Value: 2}}, f.ToSlice(iter.Of(1, 2)))
+	assert.Equal(
+		t,
+		[]interface{}{iter.KeyValue{Key: 1, Value: 2}, iter.KeyValue{Key: 2, Value: 3}},
+		f.ToSlice(iter.Of(1, 2, 3)),
+	)
+}
+
+func TestFinisherPeekIndexed(t *testing.T) {
+	var (
+		indexes  []int
+		elements []interface{}
+		fn       = func(index int, element interface{}) {
+			indexes = append(indexes, index)
+			elements = append(elements, element)
+		}
+		f = NewFinisher().PeekIndexed(fn)
+	)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []int(nil), indexes)
+
+	assert.Equal(t, []interface{}{"a", "b", "c"}, f.ToSlice(iter.Of("a", "b", "c")))
+	assert.Equal(t, []int{0, 1, 2}, indexes)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, elements)
+
+	// Indexes continue across chunks of a parallel execution, and f is called in order
+	indexes, elements = nil, nil
+	f = New().Map(func(element interface{}) interface{} { return element }).AndFinish().PeekIndexed(fn)
+	assert.Equal(
+		t,
+		[]interface{}{"a", "b", "c", "d", "e"},
+		f.ToSlice(iter.Of("a", "b", "c", "d", "e"), ParallelConfig{NumberOfItems: 2, Flags: NumberOfItemsPerGoroutine}),
+	)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, indexes)
+	assert.Equal(t, []interface{}{"a", "b", "c", "d", "e"}, elements)
+}
+
 func TestFinisherReverseSort(t *testing.T) {
 	f := NewFinisher().ReverseSort(funcs.IntSortFunc)
 	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
@@ -162,16 +437,171 @@ func TestFinisherSkip(t *testing.T) {
 	assert.Equal(t, []interface{}{}, f.Iter(iter.Of(1, 2)).ToSlice())
 	assert.Equal(t, []interface{}{3}, f.Iter(iter.Of(1, 2, 3)).ToSlice())
 	assert.Equal(t, []interface{}{3, 4}, f.Iter(iter.Of(1, 2, 3, 4)).ToSlice())
+
+	// Skip nothing
+	f = NewFinisher().Skip(0)
+	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{1, 2}, f.Iter(iter.Of(1, 2)).ToSlice())
+
+	// Negative
+	func() {
+		defer func() {
+			assert.Equal(t, ErrNegativeSkip, recover())
+		}()
+
+		NewFinisher().Skip(-1)
+		assert.Fail(t, "Must panic")
+	}()
 }
 
 func TestFinisherSort(t *testing.T) {
 	f := NewFinisher().Sort(funcs.IntSortFunc)
 	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
 	assert.Equal(t, []interface{}{1, 2, 3}, f.Iter(iter.Of(2, 3, 1)).ToSlice())
+
+	// Sort structs by a field
+	type person struct {
+		ID   int
+		Name string
+	}
+
+	getID := func(p interface{}) interface{} { return p.(person).ID }
+	f = NewFinisher().Sort(funcs.ComparingBy(getID, funcs.IntSortFunc))
+	assert.Equal(
+		t,
+		[]interface{}{person{1, "Carol"}, person{2, "Bob"}, person{3, "Alice"}},
+		f.Iter(iter.Of(person{3, "Alice"}, person{1, "Carol"}, person{2, "Bob"})).ToSlice(),
+	)
+}
+
+func TestFinisherSplitBy(t *testing.T) {
+	var (
+		isSep = func(element interface{}) bool { return element == "" }
+		f     = NewFinisher().SplitBy(isSep)
+	)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{[]interface{}{"a"}}, f.ToSlice(iter.Of("a")))
+	assert.Equal(t, []interface{}{[]interface{}{}, []interface{}{}}, f.ToSlice(iter.Of("")))
+
+	// Paragraphs, with consecutive separators producing an empty group
+	assert.Equal(
+		t,
+		[]interface{}{
+			[]interface{}{"line 1", "line 2"},
+			[]interface{}{"line 3"},
+			[]interface{}{},
+			[]interface{}{"line 4"},
+		},
+		f.ToSlice(iter.Of("line 1", "line 2", "", "line 3", "", "", "line 4")),
+	)
+
+	// Leading and trailing separators
+	assert.Equal(
+		t,
+		[]interface{}{[]interface{}{}, []interface{}{"a"}, []interface{}{}},
+		f.ToSlice(iter.Of("", "a", "")),
+	)
+}
+
+func TestFinisherSplitLarge(t *testing.T) {
+	var (
+		splitter = func(element interface{}, maxSize int) []interface{} {
+			var (
+				str    = element.(string)
+				pieces = []interface{}{}
+			)
+
+			for len(str) > maxSize {
+				pieces = append(pieces, str[:maxSize])
+				str = str[maxSize:]
+			}
+
+			return append(pieces, str)
+		}
+		f = NewFinisher().SplitLarge(3, splitter)
+	)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{"ab"}, f.ToSlice(iter.Of("ab")))
+	assert.Equal(t, []interface{}{"abc"}, f.ToSlice(iter.Of("abc")))
+	assert.Equal(t, []interface{}{"abc", "def", "g", "hi", "jkl", "mno"}, f.ToSlice(iter.Of("abcdefg", "hi", "jklmno")))
+
+	// Elements that split into no pieces are dropped
+	f = NewFinisher().SplitLarge(3, func(element interface{}, maxSize int) []interface{} {
+		if element.(string) == "" {
+			return nil
+		}
+
+		return splitter(element, maxSize)
+	})
+	assert.Equal(t, []interface{}{"abc", "d", "ef"}, f.ToSlice(iter.Of("", "abcd", "", "ef", "")))
 }
 
 // ==== Terminals
 
+func TestFinisherUntil(t *testing.T) {
+	var (
+		isNul = func(element interface{}) bool { return element.(byte) == 0 }
+		f     = NewFinisher().Until(isNul)
+	)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of(byte(0), byte('a'))))
+
+	// No sentinel
+	assert.Equal(t, []interface{}{byte('a'), byte('b')}, f.ToSlice(iter.OfReader(strings.NewReader("ab"))))
+
+	// Stop at NUL, leaving the remaining bytes unread
+	src := iter.OfReader(strings.NewReader("ab\x00cd"))
+	assert.Equal(t, []interface{}{byte('a'), byte('b')}, f.ToSlice(src))
+	assert.Equal(t, []interface{}{byte('c'), byte('d')}, src.ToSlice())
+
+	// Elements after the sentinel are not iterated, even if they are in a later chunk of a parallel execution
+	f = New().
+		Map(func(element interface{}) interface{} { return element }).
+		AndFinish().
+		Until(func(element interface{}) bool { return element.(int) == 4 })
+	assert.Equal(
+		t,
+		[]interface{}{0, 1, 2, 3},
+		f.ToSlice(iter.Of(0, 1, 2, 3, 4, 5, 6, 7, 8, 9), ParallelConfig{NumberOfItems: 3, Flags: NumberOfItemsPerGoroutine}),
+	)
+}
+
+func TestFinisherWithIndex(t *testing.T) {
+	f := New().
+		Filter(func(element interface{}) bool { return element.(int)%2 == 0 }).
+		AndFinish().
+		WithIndex(1)
+
+	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
+	assert.Equal(
+		t,
+		[]interface{}{
+			iter.KeyValue{Key: 1, Value: 2},
+			iter.KeyValue{Key: 2, Value: 4},
+			iter.KeyValue{Key: 3, Value: 6},
+		},
+		f.ToSlice(iter.Of(1, 2, 3, 4, 5, 6)),
+	)
+
+	// Each terminal call starts again at start
+	assert.Equal(t, []interface{}{iter.KeyValue{Key: 1, Value: 8}}, f.ToSlice(iter.Of(8)))
+
+	// Indexes continue across chunks of a parallel execution
+	assert.Equal(
+		t,
+		[]interface{}{
+			iter.KeyValue{Key: 1, Value: 2},
+			iter.KeyValue{Key: 2, Value: 4},
+			iter.KeyValue{Key: 3, Value: 6},
+			iter.KeyValue{Key: 4, Value: 8},
+		},
+		f.ToSlice(iter.Of(1, 2, 3, 4, 5, 6, 7, 8), ParallelConfig{NumberOfItems: 3, Flags: NumberOfItemsPerGoroutine}),
+	)
+}
+
 func TestFinisherIter(t *testing.T) {
 	f := NewFinisher()
 	assert.Equal(t, []interface{}{}, f.Iter(iter.Of()).ToSlice())
@@ -194,6 +624,20 @@ func TestFinisherAnyMatch(t *testing.T) {
 	assert.False(t, f.AnyMatch(fn, iter.Of(3)))
 }
 
+func TestFinisherAssociateBy(t *testing.T) {
+	var (
+		keyFn = func(element interface{}) interface{} { return element.(string)[0:1] }
+		f     = NewFinisher()
+	)
+
+	assert.Equal(t, map[interface{}]interface{}{}, f.AssociateBy(keyFn, iter.Of()))
+	assert.Equal(
+		t,
+		map[interface{}]interface{}{"a": "a3", "b": "b1", "c": "c1"},
+		f.AssociateBy(keyFn, iter.Of("a1", "b1", "a2", "c1", "a3")),
+	)
+}
+
 func TestFinisherAverage(t *testing.T) {
 	f := NewFinisher()
 	assert.True(t, f.Average(iter.Of()).IsEmpty())
@@ -201,10 +645,100 @@ func TestFinisherAverage(t *testing.T) {
 	assert.Equal(t, 3.0, f.Average(iter.Of(3)).MustGet())
 }
 
+func TestFinisherAverageAsBigRat(t *testing.T) {
+	f := NewFinisher()
+	assert.True(t, f.AverageAsBigRat(iter.Of()).IsEmpty())
+	assert.Equal(t, big.NewRat(3, 2), f.AverageAsBigRat(iter.Of(1, 2)).MustGet())
+	assert.Equal(t, big.NewRat(7, 4), f.AverageAsBigRat(iter.Of(1.5, uint8(2))).MustGet())
+
+	// Average of values beyond float64's exact integer range
+	var (
+		max = big.NewInt(math.MaxInt64)
+		ref = new(big.Rat).SetInt(new(big.Int).Add(max, big.NewInt(1)))
+	)
+	assert.Equal(t, 0, ref.Cmp(f.AverageAsBigRat(iter.Of(int64(math.MaxInt64), new(big.Int).Add(max, big.NewInt(2)))).MustGet().(*big.Rat)))
+}
+
+func TestFinisherCollect(t *testing.T) {
+	var (
+		supplier    = func() interface{} { return &strings.Builder{} }
+		accumulator = func(acc, element interface{}) interface{} {
+			acc.(*strings.Builder).WriteString(element.(string))
+			return acc
+		}
+		finisher = func(acc interface{}) interface{} { return acc.(*strings.Builder).String() }
+		f        = NewFinisher()
+	)
+
+	assert.Equal(t, "", f.Collect(supplier, accumulator, finisher, iter.Of()))
+	assert.Equal(t, "abc", f.Collect(supplier, accumulator, finisher, iter.Of("a", "b", "c")))
+	assert.Equal(t, "abc", f.Collect(supplier, accumulator, finisher, iter.Of("a", "b", "c"), ParallelConfig{}))
+}
+
+func TestFinisherCollectMulti(t *testing.T) {
+	var (
+		slice      []interface{}
+		sum        int
+		m          = map[interface{}]bool{}
+		collectors = []func(element interface{}){
+			func(element interface{}) { slice = append(slice, element) },
+			func(element interface{}) { sum += element.(int) },
+			func(element interface{}) { m[element] = true },
+		}
+		f = NewFinisher().Filter(func() func(element interface{}) bool {
+			return func(element interface{}) bool { return element.(int) > 1 }
+		})
+	)
+
+	f.CollectMulti(collectors, iter.Of())
+	assert.Nil(t, slice)
+	assert.Equal(t, 0, sum)
+	assert.Equal(t, map[interface{}]bool{}, m)
+
+	f.CollectMulti(collectors, iter.Of(1, 2, 3, 4))
+	assert.Equal(t, []interface{}{2, 3, 4}, slice)
+	assert.Equal(t, 9, sum)
+	assert.Equal(t, map[interface{}]bool{2: true, 3: true, 4: true}, m)
+}
+
 func TestFinisherCount(t *testing.T) {
 	f := NewFinisher()
 	assert.Equal(t, 0, f.Count(iter.Of()))
 	assert.Equal(t, 2, f.Count(iter.Of(1, 2)))
+
+	// Fast path does not advance the source
+	src := iter.Of(1, 2, 3)
+	assert.Equal(t, 3, f.Count(src))
+	assert.Equal(t, []interface{}{1, 2, 3}, src.ToSlice())
+
+	// Source of unknown length is iterated
+	src = iter.OfReader(strings.NewReader("abcd"))
+	assert.Equal(t, 4, f.Count(src))
+	assert.False(t, src.Next())
+
+	// Transforms and parallel execution iterate the source
+	src = iter.Of(1, 2, 3)
+	assert.Equal(t, 2, f.Filter(func() func(interface{}) bool {
+		return func(element interface{}) bool { return element.(int) > 1 }
+	}).Count(src))
+	assert.False(t, src.Next())
+	assert.Equal(t, 3, f.Count(iter.Of(1, 2, 3), ParallelConfig{NumberOfItems: 2}))
+}
+
+func TestFinisherFind(t *testing.T) {
+	var (
+		pred = func(element interface{}) bool { return element.(int)%2 == 0 }
+		f    = NewFinisher()
+	)
+
+	// Not found
+	assert.True(t, f.Find(pred, iter.Of()).IsEmpty())
+	assert.True(t, f.Find(pred, iter.Of(1, 3)).IsEmpty())
+
+	// Found, and stops early
+	it := iter.Of(1, 4, 6, 7)
+	assert.Equal(t, 4, f.Find(pred, it).MustGet())
+	assert.Equal(t, 6, it.NextValue())
 }
 
 func TestFinisherFirst(t *testing.T) {
@@ -215,6 +749,40 @@ func TestFinisherFirst(t *testing.T) {
 	assert.Equal(t, 3, f.First(iter.Of(1, 2, 3)).MustGet())
 }
 
+func TestFinisherFirstMatch(t *testing.T) {
+	var (
+		pred = func(element interface{}) bool { return element.(int) > 2 }
+		f    = NewFinisher()
+	)
+
+	assert.True(t, f.FirstMatch(pred, iter.Of()).IsEmpty())
+	assert.True(t, f.FirstMatch(pred, iter.Of(1, 2)).IsEmpty())
+	assert.Equal(t, 3, f.FirstMatch(pred, iter.Of(1, 3, 2, 4)).MustGet())
+	assert.Equal(t, 3, f.FirstMatch(pred, iter.Of(1, 3, 2, 4), ParallelConfig{}).MustGet())
+
+	// Serial mode stops reading once a match is found
+	it := iter.Of(1, 3, 2, 4)
+	assert.Equal(t, 3, f.FirstMatch(pred, it).MustGet())
+	assert.Equal(t, 2, it.NextValue())
+}
+
+func TestFinisherFlattenToSlice(t *testing.T) {
+	f := NewFinisher()
+	assert.Equal(t, []interface{}{}, f.FlattenToSlice(iter.Of()))
+	assert.Equal(t, []interface{}{1}, f.FlattenToSlice(iter.Of(1)))
+	assert.Equal(t, []interface{}{}, f.FlattenToSlice(iter.Of([]int{})))
+	assert.Equal(
+		t,
+		[]interface{}{1, 2, 3, "a", 4, 5, []int{6, 7}, nil},
+		f.FlattenToSlice(iter.Of(1, []int{2, 3}, "a", [2]int{4, 5}, [][]int{{6, 7}}, nil)),
+	)
+	assert.Equal(
+		t,
+		[]interface{}{1, 2, 3},
+		f.FlattenToSlice(iter.Of([]int{1}, 2, []int{3}), ParallelConfig{}),
+	)
+}
+
 func TestFinisherForEach(t *testing.T) {
 	var elements []interface{}
 	fn := func(element interface{}) {
@@ -233,6 +801,63 @@ func TestFinisherForEach(t *testing.T) {
 	assert.Equal(t, []interface{}{1, 2, 3}, elements)
 }
 
+func TestFinisherForEachParallel(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		processed = map[interface{}]bool{}
+		fn        = func(element interface{}) {
+			mu.Lock()
+			defer mu.Unlock()
+			processed[element] = true
+		}
+		f    = NewFinisher().Filter(func() func(interface{}) bool { return func(element interface{}) bool { return element.(int) > 0 } })
+		data = make([]interface{}, 100)
+		exp  = map[interface{}]bool{}
+	)
+
+	for i := range data {
+		data[i] = i
+		if i > 0 {
+			exp[i] = true
+		}
+	}
+
+	// All transformed elements are processed
+	f.ForEachParallel(fn, 4, iter.Of(data...))
+	assert.Equal(t, exp, processed)
+
+	processed = map[interface{}]bool{}
+	f.ForEachParallel(fn, 4, iter.Of())
+	assert.Equal(t, map[interface{}]bool{}, processed)
+
+	// Panic in consumer is propagated
+	func() {
+		defer func() {
+			assert.Equal(t, "bad 3", recover())
+		}()
+
+		NewFinisher().ForEachParallel(
+			func(element interface{}) {
+				if element == 3 {
+					panic(fmt.Sprintf("bad %d", element))
+				}
+			},
+			2,
+			iter.Of(1, 2, 3, 4),
+		)
+		assert.Fail(t, "Must panic")
+	}()
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrInvalidConcurrency, recover())
+		}()
+
+		NewFinisher().ForEachParallel(fn, 0, iter.Of())
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestFinisherGroupBy(t *testing.T) {
 	fn := func(element interface{}) (key interface{}) {
 		return element.(int) % 3
@@ -243,6 +868,86 @@ func TestFinisherGroupBy(t *testing.T) {
 	assert.Equal(t, map[interface{}][]interface{}{0: {0}, 1: {1, 4}}, f.GroupBy(fn, iter.Of(0, 1, 4)))
 }
 
+func TestFinisherGroupByJoin(t *testing.T) {
+	fn := func(element interface{}) (key interface{}) {
+		return element.(string)[0:1]
+	}
+	f := NewFinisher()
+	assert.Equal(t, map[interface{}]string{}, f.GroupByJoin(fn, ", ", iter.Of()))
+	assert.Equal(t, map[interface{}]string{"a": "apple"}, f.GroupByJoin(fn, ", ", iter.Of("apple")))
+	assert.Equal(
+		t,
+		map[interface{}]string{"a": "apple, avocado, apricot", "b": "banana, blueberry", "c": "cherry"},
+		f.GroupByJoin(fn, ", ", iter.Of("apple", "banana", "avocado", "cherry", "blueberry", "apricot")),
+	)
+
+	// Elements convertible to string
+	type word string
+	assert.Equal(
+		t,
+		map[interface{}]string{"a": "ab-ac"},
+		f.GroupByJoin(func(element interface{}) interface{} { return "a" }, "-", iter.Of(word("ab"), word("ac"))),
+	)
+
+	// Elements not convertible to string
+	func() {
+		defer func() {
+			assert.Equal(t, iter.ConversionError{Value: 1.5, TargetType: reflect.TypeOf(""), Cause: iter.CauseIncompatibleType}, recover())
+		}()
+
+		f.GroupByJoin(func(element interface{}) interface{} { return "a" }, "-", iter.Of("ab", 1.5))
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestFinisherGroupByMulti(t *testing.T) {
+	type sale struct {
+		region   string
+		category string
+		amount   int
+	}
+
+	var (
+		f      = NewFinisher()
+		region = func(element interface{}) interface{} { return element.(sale).region }
+		categ  = func(element interface{}) interface{} { return element.(sale).category }
+		s1     = sale{"east", "books", 1}
+		s2     = sale{"west", "books", 2}
+		s3     = sale{"east", "games", 3}
+		s4     = sale{"east", "books", 4}
+		data   = []interface{}{s1, s2, s3, s4}
+	)
+
+	// Two levels
+	assert.Equal(
+		t,
+		map[interface{}]interface{}{
+			"east": map[interface{}]interface{}{
+				"books": []interface{}{s1, s4},
+				"games": []interface{}{s3},
+			},
+			"west": map[interface{}]interface{}{
+				"books": []interface{}{s2},
+			},
+		},
+		f.GroupByMulti([]func(interface{}) interface{}{region, categ}, iter.Of(data...)),
+	)
+
+	// One level
+	assert.Equal(
+		t,
+		map[interface{}]interface{}{
+			"books": []interface{}{s1, s2, s4},
+			"games": []interface{}{s3},
+		},
+		f.GroupByMulti([]func(interface{}) interface{}{categ}, iter.Of(data...), ParallelConfig{NumberOfItems: 2}),
+	)
+
+	// No levels, and no elements
+	assert.Equal(t, data, f.GroupByMulti(nil, iter.Of(data...)))
+	assert.Equal(t, map[interface{}]interface{}{}, f.GroupByMulti([]func(interface{}) interface{}{region}, iter.Of()))
+}
+
 func TestFinisherLast(t *testing.T) {
 	f := NewFinisher()
 	assert.True(t, f.Last(iter.Of()).IsEmpty())
@@ -250,6 +955,19 @@ func TestFinisherLast(t *testing.T) {
 	assert.Equal(t, 2, f.Last(iter.Of(1, 2)).MustGet())
 }
 
+func TestFinisherLastMatch(t *testing.T) {
+	var (
+		pred = func(element interface{}) bool { return element.(int) > 2 }
+		f    = NewFinisher()
+	)
+
+	assert.True(t, f.LastMatch(pred, iter.Of()).IsEmpty())
+	assert.True(t, f.LastMatch(pred, iter.Of(1, 2)).IsEmpty())
+	assert.Equal(t, 3, f.LastMatch(pred, iter.Of(1, 3, 2)).MustGet())
+	assert.Equal(t, 4, f.LastMatch(pred, iter.Of(1, 3, 2, 4, 1)).MustGet())
+	assert.Equal(t, 4, f.LastMatch(pred, iter.Of(1, 3, 2, 4, 1), ParallelConfig{}).MustGet())
+}
+
 func TestFinisherMax(t *testing.T) {
 	f := NewFinisher()
 	assert.True(t, f.Max(funcs.IntSortFunc, iter.Of()).IsEmpty())
@@ -266,6 +984,38 @@ func TestFinisherMin(t *testing.T) {
 	assert.Equal(t, 3, f.Min(funcs.IntSortFunc, iter.Of(4, 3, 5)).MustGet())
 }
 
+func TestFinisherMaxNMinN(t *testing.T) {
+	var (
+		f    = NewFinisher()
+		data = []interface{}{5, 3, 9, 1, 7, 10, 2, 8, 4, 6}
+	)
+
+	// Max
+	assert.Equal(t, []interface{}{}, f.MaxN(3, funcs.IntSortFunc, iter.Of()))
+	assert.Equal(t, []interface{}{}, f.MaxN(0, funcs.IntSortFunc, iter.Of(data...)))
+	assert.Equal(t, []interface{}{10, 9, 8}, f.MaxN(3, funcs.IntSortFunc, iter.Of(data...)))
+	assert.Equal(t, []interface{}{10, 9, 8}, f.MaxN(3, funcs.IntSortFunc, iter.Of(data...), ParallelConfig{}))
+	assert.Equal(t, []interface{}{3, 2, 1}, f.MaxN(5, funcs.IntSortFunc, iter.Of(2, 3, 1)))
+
+	// Min
+	assert.Equal(t, []interface{}{}, f.MinN(3, funcs.IntSortFunc, iter.Of()))
+	assert.Equal(t, []interface{}{1, 2, 3}, f.MinN(3, funcs.IntSortFunc, iter.Of(data...)))
+	assert.Equal(t, []interface{}{1, 2, 3}, f.MinN(5, funcs.IntSortFunc, iter.Of(2, 3, 1)))
+}
+
+func TestFinisherMode(t *testing.T) {
+	f := NewFinisher()
+	assert.True(t, f.Mode(iter.Of()).IsEmpty())
+	assert.Equal(t, "a", f.Mode(iter.Of("a")).MustGet())
+
+	// One element dominates
+	assert.Equal(t, "b", f.Mode(iter.Of("a", "b", "c", "b", "a", "b")).MustGet())
+
+	// Tie is broken by first occurrence, even if the other element reaches the count first
+	assert.Equal(t, "a", f.Mode(iter.Of("a", "b", "b", "a")).MustGet())
+	assert.Equal(t, 3, f.Mode(iter.Of(3, 1, 2), ParallelConfig{NumberOfItems: 1}).MustGet())
+}
+
 func TestFinisherNoneMatch(t *testing.T) {
 	fn := func(element interface{}) bool { return element.(int) < 3 }
 	f := NewFinisher()
@@ -274,6 +1024,22 @@ func TestFinisherNoneMatch(t *testing.T) {
 	assert.False(t, f.NoneMatch(fn, iter.Of(1, 2, 3)))
 }
 
+func TestFinisherProduct(t *testing.T) {
+	f := NewFinisher()
+
+	// Float64
+	assert.True(t, f.Product(iter.Of()).IsEmpty())
+	assert.Equal(t, 2.5, f.Product(iter.Of(2.5)).MustGet())
+	assert.Equal(t, 7.5, f.Product(iter.Of(2, 1.5, 2.5)).MustGet())
+	assert.Equal(t, 0.0, f.Product(iter.Of(2, 0, 2.5)).MustGet())
+
+	// Int
+	assert.True(t, f.ProductAsInt(iter.Of()).IsEmpty())
+	assert.Equal(t, 3, f.ProductAsInt(iter.Of(3)).MustGet())
+	assert.Equal(t, 24, f.ProductAsInt(iter.Of(2, 3, 4)).MustGet())
+	assert.Equal(t, 0, f.ProductAsInt(iter.Of(2, 0, 4)).MustGet())
+}
+
 func TestFinisherReduce(t *testing.T) {
 	fn := func(accumulator, element2 interface{}) interface{} {
 		return accumulator.(int) + element2.(int)
@@ -283,6 +1049,163 @@ func TestFinisherReduce(t *testing.T) {
 	assert.Equal(t, 7, f.Reduce(1, fn, iter.Of(1, 2, 3)))
 }
 
+func TestFinisherReduceParallel(t *testing.T) {
+	var (
+		sum   = func(accumulator, element interface{}) interface{} { return accumulator.(int) + element.(int) }
+		merge = func(accumulator1, accumulator2 interface{}) interface{} {
+			return accumulator1.(int) + accumulator2.(int)
+		}
+		f    = New().Map(func(element interface{}) interface{} { return element.(int) * 2 }).AndFinish()
+		data = make([]interface{}, 1000)
+	)
+
+	for i := range data {
+		data[i] = i
+	}
+
+	serial := f.Reduce(0, sum, iter.Of(data...))
+	assert.Equal(t, 999000, serial)
+
+	// Parallel sum matches serial sum
+	assert.Equal(t, serial, f.ReduceParallel(0, sum, merge, iter.Of(data...)))
+	assert.Equal(t, serial, f.ReduceParallel(0, sum, merge, iter.Of(data...), ParallelConfig{}))
+	assert.Equal(t, serial, f.ReduceParallel(0, sum, merge, iter.Of(data...), ParallelConfig{NumberOfItems: 7, Flags: NumberOfItemsPerGoroutine}))
+	assert.Equal(t, serial, f.ReduceParallel(0, sum, merge, iter.Of(data...), ParallelConfig{Flags: Serial}))
+
+	// Empty
+	assert.Equal(t, 0, f.ReduceParallel(0, sum, merge, iter.Of(), ParallelConfig{}))
+
+	// Associative but not commutative operation preserves order
+	var (
+		concat = func(accumulator, element interface{}) interface{} {
+			return accumulator.(string) + strconv.Itoa(element.(int))
+		}
+		mergeConcat = func(accumulator1, accumulator2 interface{}) interface{} {
+			return accumulator1.(string) + accumulator2.(string)
+		}
+	)
+	assert.Equal(
+		t,
+		f.Reduce("", concat, iter.Of(data...)),
+		f.ReduceParallel("", concat, mergeConcat, iter.Of(data...), ParallelConfig{NumberOfItems: 3}),
+	)
+}
+
+func TestFinisherShard(t *testing.T) {
+	var (
+		f     = NewFinisher()
+		keyFn = func(element interface{}) interface{} { return element.(string)[:1] }
+		data  = []interface{}{"a1", "b1", "c1", "a2", "d1", "b2", "a3", "e1", "c2"}
+	)
+
+	// Shard count is exactly n, even if shards are empty
+	assert.Equal(t, [][]interface{}{{}, {}, {}}, f.Shard(3, keyFn, iter.Of()))
+	assert.Equal(t, [][]interface{}{data}, f.Shard(1, keyFn, iter.Of(data...)))
+
+	shards := f.Shard(4, keyFn, iter.Of(data...))
+	assert.Equal(t, 4, len(shards))
+
+	// Elements with the same key are in the same shard, in order
+	var (
+		shardOf   = map[string]int{}
+		lastOfKey = map[string]string{}
+		count     int
+	)
+
+	for i, shard := range shards {
+		for _, element := range shard {
+			key := keyFn(element).(string)
+			if j, haveIt := shardOf[key]; haveIt {
+				assert.Equal(t, j, i)
+				assert.True(t, lastOfKey[key] < element.(string))
+			}
+
+			shardOf[key], lastOfKey[key] = i, element.(string)
+			count++
+		}
+	}
+	assert.Equal(t, len(data), count)
+
+	// Sharding is deterministic
+	assert.Equal(t, shards, f.Shard(4, keyFn, iter.Of(data...), ParallelConfig{NumberOfItems: 2}))
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrInvalidShardCount, recover())
+		}()
+
+		f.Shard(0, keyFn, iter.Of())
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestFinisherSingle(t *testing.T) {
+	f := NewFinisher()
+
+	single, err := f.Single(iter.Of())
+	assert.True(t, single.IsEmpty())
+	assert.Nil(t, err)
+
+	single, err = f.Single(iter.Of(1))
+	assert.Equal(t, 1, single.MustGet())
+	assert.Nil(t, err)
+
+	single, err = f.Single(iter.Of(1, 2))
+	assert.True(t, single.IsEmpty())
+	assert.EqualError(t, err, ErrMoreThanOneElement)
+
+	// Only transformed elements are counted
+	f = f.Filter(func() func(element interface{}) bool {
+		return func(element interface{}) bool { return element.(int) > 2 }
+	})
+	single, err = f.Single(iter.Of(1, 2, 3))
+	assert.Equal(t, 3, single.MustGet())
+	assert.Nil(t, err)
+}
+
+func TestFinisherStatistics(t *testing.T) {
+	f := NewFinisher()
+
+	// Empty
+	count, mean, variance, min, max := f.Statistics(iter.Of())
+	assert.Equal(t, 0, count)
+	assert.Equal(t, 0.0, mean)
+	assert.Equal(t, 0.0, variance)
+	assert.Equal(t, 0.0, min)
+	assert.Equal(t, 0.0, max)
+
+	// Known dataset
+	count, mean, variance, min, max = f.Statistics(iter.Of(2, 4, 4, 4, 5, 5, 7, 9))
+	assert.Equal(t, 8, count)
+	assert.Equal(t, 5.0, mean)
+	assert.Equal(t, 4.0, variance)
+	assert.Equal(t, 2.0, min)
+	assert.Equal(t, 9.0, max)
+
+	// Large offset, compared against a two pass reference computation
+	data := []interface{}{}
+	for i := 0; i < 100; i++ {
+		data = append(data, 1e9+float64(i%7)+0.25)
+	}
+
+	var refMean, refVariance float64
+	for _, val := range data {
+		refMean += val.(float64)
+	}
+	refMean /= float64(len(data))
+	for _, val := range data {
+		refVariance += (val.(float64) - refMean) * (val.(float64) - refMean)
+	}
+	refVariance /= float64(len(data))
+
+	count, mean, variance, min, max = f.Statistics(iter.Of(data...), ParallelConfig{NumberOfItems: 10})
+	assert.Equal(t, 100, count)
+	assert.InDelta(t, refMean, mean, 1e-6)
+	assert.InDelta(t, refVariance, variance, 1e-6)
+	assert.Equal(t, 1e9+0.25, min)
+	assert.Equal(t, 1e9+6.25, max)
+}
+
 func TestFinisherSum(t *testing.T) {
 	f := NewFinisher()
 
@@ -319,6 +1242,70 @@ func TestFinisherToMapOf(t *testing.T) {
 	assert.Equal(t, map[int]string{1: "1", 2: "2", 3: "3"}, f.ToMapOf(fn, 0, "0", iter.Of(1, 2, 3)))
 }
 
+func TestFinisherSumAsBigFloat(t *testing.T) {
+	f := NewFinisher()
+	assert.True(t, f.SumAsBigFloat(iter.Of()).IsEmpty())
+	assert.Equal(t, "3.25", f.SumAsBigFloat(iter.Of(1, 2.25)).MustGet().(*big.Float).String())
+
+	// Sum of values that exceed float64's exact integer range of 2^53 matches a big.Int reference
+	var (
+		vals = []interface{}{int64(1) << 53, 1, uint64(math.MaxUint64), json.Number("12345678901234567890"), new(big.Int).Lsh(big.NewInt(1), 100)}
+		ref  = new(big.Int)
+	)
+	for _, v := range []string{"9007199254740992", "1", "18446744073709551615", "12345678901234567890", "1267650600228229401496703205376"} {
+		n, _ := new(big.Int).SetString(v, 10)
+		ref.Add(ref, n)
+	}
+
+	sum, acc := f.SumAsBigFloat(iter.Of(vals...)).MustGet().(*big.Float).Int(nil)
+	assert.Equal(t, big.Exact, acc)
+	assert.Equal(t, 0, ref.Cmp(sum))
+
+	// The float64 sum loses the 1, the big.Float sum does not
+	assert.Equal(t, float64(1<<53), f.Sum(iter.Of(int64(1)<<53, 1)).MustGet())
+	assert.Equal(t, "9007199254740993", f.SumAsBigFloat(iter.Of(int64(1)<<53, 1)).MustGet().(*big.Float).Text('f', 0))
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrInvalidBigRat, recover())
+		}()
+
+		f.SumAsBigFloat(iter.Of("1"))
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestFinisherToSeq(t *testing.T) {
+	var (
+		f      = New().Map(func(element interface{}) interface{} { return element.(int) * 2 }).AndFinish()
+		result []interface{}
+		yield  = func(element interface{}) bool {
+			result = append(result, element)
+			return true
+		}
+	)
+
+	f.ToSeq(iter.Of())(yield)
+	assert.Equal(t, []interface{}(nil), result)
+
+	f.ToSeq(iter.Of(1, 2, 3))(yield)
+	assert.Equal(t, []interface{}{2, 4, 6}, result)
+
+	// Breaking out stops consuming the source
+	var (
+		source = iter.Of(1, 2, 3, 4)
+		seq    = f.ToSeq(source)
+	)
+
+	result = nil
+	seq(func(element interface{}) bool {
+		result = append(result, element)
+		return element.(int) < 4
+	})
+	assert.Equal(t, []interface{}{2, 4}, result)
+	assert.Equal(t, 3, source.NextValue())
+}
+
 func TestFinisherToSlice(t *testing.T) {
 	f := NewFinisher()
 	assert.Equal(t, []interface{}{}, f.ToSlice(iter.Of()))
@@ -331,6 +1318,144 @@ func TestFinisherToSliceOf(t *testing.T) {
 	assert.Equal(t, []int{1, 2}, f.ToSliceOf(0, iter.Of(1, 2)))
 }
 
+func TestFinisherToSliceOfLenient(t *testing.T) {
+	var (
+		f       = NewFinisher()
+		skipped []interface{}
+		errs    []error
+		onError = func(element interface{}, err error) {
+			skipped = append(skipped, element)
+			errs = append(errs, err)
+		}
+	)
+
+	assert.Equal(t, []int{}, f.ToSliceOfLenient(0, onError, iter.Of()))
+	assert.Equal(t, []int{1, 2}, f.ToSliceOfLenient(0, onError, iter.Of(1, 2.5)))
+	assert.Nil(t, skipped)
+
+	// Unconvertible elements are skipped
+	assert.Equal(t, []int{1, 3}, f.ToSliceOfLenient(0, onError, iter.Of(1, "2", 3, nil, []int{4})))
+	assert.Equal(t, []interface{}{"2", nil, []int{4}}, skipped)
+	assert.Equal(
+		t,
+		[]error{
+			iter.ConversionError{Value: "2", TargetType: reflect.TypeOf(0), Cause: iter.CauseIncompatibleType},
+			iter.ConversionError{Value: nil, TargetType: reflect.TypeOf(0), Cause: iter.CauseNilValue},
+			iter.ConversionError{Value: []int{4}, TargetType: reflect.TypeOf(0), Cause: iter.CauseIncompatibleType},
+		},
+		errs,
+	)
+
+	// A slice that is too short to convert to an array is skipped
+	skipped, errs = nil, nil
+	assert.Equal(t, [][2]int{{1, 2}}, f.ToSliceOfLenient([2]int{}, onError, iter.Of([]int{1, 2}, []int{3})))
+	assert.Equal(t, []interface{}{[]int{3}}, skipped)
+	assert.Equal(
+		t,
+		[]error{iter.ConversionError{Value: []int{3}, TargetType: reflect.TypeOf([2]int{}), Cause: iter.CauseIncompatibleType}},
+		errs,
+	)
+}
+
+func TestFinisherToSliceReversed(t *testing.T) {
+	f := NewFinisher()
+	assert.Equal(t, []interface{}{}, f.ToSliceReversed(iter.Of()))
+	assert.Equal(t, []interface{}{1}, f.ToSliceReversed(iter.Of(1)))
+	assert.Equal(t, []interface{}{2, 1}, f.ToSliceReversed(iter.Of(1, 2)))
+	assert.Equal(t, []interface{}{5, 4, 3, 2, 1}, f.ToSliceReversed(iter.Of(1, 2, 3, 4, 5), ParallelConfig{NumberOfItems: 2}))
+
+	// Reverses the transformed elements
+	assert.Equal(t, []interface{}{3, 1}, f.Filter(func() func(interface{}) bool {
+		return func(element interface{}) bool { return element.(int)%2 == 1 }
+	}).ToSliceReversed(iter.Of(1, 2, 3, 4)))
+}
+
+func TestFinisherToSortedSliceOf(t *testing.T) {
+	var (
+		f    = NewFinisher()
+		desc = funcs.Reverse(funcs.IntSortFunc)
+	)
+
+	assert.Equal(t, []int{}, f.ToSortedSliceOf(0, desc, iter.Of()))
+	assert.Equal(t, []int{3, 2, 1}, f.ToSortedSliceOf(0, desc, iter.Of(2, 3, 1)))
+	assert.Equal(t, []int{1, 2, 3}, f.ToSortedSliceOf(0, funcs.IntSortFunc, iter.Of(2, 3, 1), ParallelConfig{NumberOfItems: 1}))
+
+	// Elements are converted before sorting
+	assert.Equal(t, []string{"c", "b", "a"}, f.ToSortedSliceOf("", funcs.Reverse(funcs.StringSortFunc), iter.Of('b', 'a', 'c')))
+}
+
+func TestFinisherToStructByFieldKeys(t *testing.T) {
+	type Person struct {
+		FirstName string
+		Age       int
+	}
+
+	var (
+		f            = NewFinisher()
+		startsRecord = func(element interface{}) bool { return element.(iter.KeyValue).Key == "FirstName" }
+	)
+
+	assert.Equal(t, []Person{}, f.ToStructByFieldKeys(Person{}, startsRecord, iter.Of()))
+	assert.Equal(
+		t,
+		[]Person{{FirstName: "Jane", Age: 56}, {FirstName: "John", Age: 65}},
+		f.ToStructByFieldKeys(
+			Person{},
+			startsRecord,
+			iter.Of(
+				iter.KeyValue{Key: "FirstName", Value: "Jane"},
+				iter.KeyValue{Key: "Age", Value: 56},
+				iter.KeyValue{Key: "FirstName", Value: "John"},
+				iter.KeyValue{Key: "Age", Value: 65},
+			),
+		),
+	)
+
+	// First element starts a record even if the predicate is false, and missing fields are zero
+	assert.Equal(
+		t,
+		[]*Person{{Age: 1}, {FirstName: "Jack"}},
+		f.ToStructByFieldKeys(
+			&Person{},
+			startsRecord,
+			iter.Of(iter.KeyValue{Key: "Age", Value: 1}, iter.KeyValue{Key: "FirstName", Value: "Jack"}),
+		),
+	)
+}
+
+func TestFinisherToStructSlice(t *testing.T) {
+	type Person struct {
+		FirstName string
+		Age       int
+	}
+
+	var (
+		f    = NewFinisher()
+		jane = map[string]interface{}{"firstName": "Jane", "age": 56}
+		john = map[string]interface{}{"firstName": "John", "age": 65}
+	)
+
+	assert.Equal(t, []Person{}, f.ToStructSlice(Person{}, iter.Of()))
+	assert.Equal(
+		t,
+		[]Person{{FirstName: "Jane", Age: 56}, {FirstName: "John", Age: 65}},
+		f.ToStructSlice(Person{}, iter.Of(jane, john)),
+	)
+
+	// Pointers and reflect.Type
+	assert.Equal(t, []*Person{{FirstName: "Jane", Age: 56}}, f.ToStructSlice(&Person{}, iter.Of(jane)))
+	assert.Equal(t, []Person{{FirstName: "John", Age: 65}}, f.ToStructSlice(reflect.TypeOf(Person{}), iter.Of(john)))
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrElementIsNotAMap, recover())
+		}()
+
+		f.ToStructSlice(Person{}, iter.Of(1))
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestToByteWriter(t *testing.T) {
 	f := NewFinisher()
 	buf := &bytes.Buffer{}
@@ -441,6 +1566,163 @@ func TestToRuneWriter(t *testing.T) {
 	assert.Equal(t, []byte(string("àḁ𝆑")), buf.Bytes())
 }
 
+// errWriter is an io.Writer that writes one byte and fails
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write(p []byte) (int, error) {
+	return 1, w.err
+}
+
+func TestToLineWriter(t *testing.T) {
+	f := NewFinisher()
+	buf := &bytes.Buffer{}
+
+	n, err := f.ToLineWriter(buf, "", iter.Of())
+	assert.Equal(t, 0, n)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(nil), buf.Bytes())
+
+	buf.Reset()
+	n, err = f.ToLineWriter(buf, "", iter.Of("one", "two", "three"))
+	assert.Equal(t, 14, n)
+	assert.Nil(t, err)
+	assert.Equal(t, "one\ntwo\nthree\n", buf.String())
+
+	buf.Reset()
+	n, err = f.ToLineWriter(buf, "\n", iter.Of("one", "two", "three"))
+	assert.Equal(t, 14, n)
+	assert.Nil(t, err)
+	assert.Equal(t, "one\ntwo\nthree\n", buf.String())
+
+	buf.Reset()
+	n, err = f.ToLineWriter(buf, "\r\n", iter.Of("one", "two", "three"))
+	assert.Equal(t, 17, n)
+	assert.Nil(t, err)
+	assert.Equal(t, "one\r\ntwo\r\nthree\r\n", buf.String())
+
+	// Lines longer than the buffer size
+	longLine := strings.Repeat("a", toWriterBufSize)
+	buf.Reset()
+	n, err = f.ToLineWriter(buf, "", iter.Of(longLine, longLine))
+	assert.Equal(t, toWriterBufSize*2+2, n)
+	assert.Nil(t, err)
+	assert.Equal(t, longLine+"\n"+longLine+"\n", buf.String())
+
+	// Writer error
+	writeErr := fmt.Errorf("write failed")
+	n, err = f.ToLineWriter(errWriter{writeErr}, "", iter.Of("one", "two"))
+	assert.Equal(t, 1, n)
+	assert.Equal(t, writeErr, err)
+}
+
+func TestToBase64Writer(t *testing.T) {
+	var (
+		f    = NewFinisher()
+		buf  = &bytes.Buffer{}
+		data = make([]byte, 1000)
+	)
+
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+
+	// Empty
+	n, err := f.ToBase64Writer(base64.StdEncoding, buf, iter.Of())
+	assert.Equal(t, 0, n)
+	assert.Nil(t, err)
+	assert.Equal(t, "", buf.String())
+
+	// Encoding then decoding round trips
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawURLEncoding} {
+		buf.Reset()
+		n, err = f.ToBase64Writer(enc, buf, iter.OfElements(data))
+		assert.Equal(t, enc.EncodedLen(len(data)), n)
+		assert.Nil(t, err)
+		assert.Equal(t, enc.EncodeToString(data), buf.String())
+
+		decoded := Base64Decode(enc)()(iter.OfElements(buf.Bytes())).ToSliceOf(byte(0))
+		assert.Equal(t, data, decoded)
+	}
+
+	// Elements converted via ByteValue
+	buf.Reset()
+	f.ToBase64Writer(base64.StdEncoding, buf, iter.Of(1, 2, 3))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte{1, 2, 3}), buf.String())
+
+	// Write error
+	writeErr := fmt.Errorf("write failed")
+	n, err = f.ToBase64Writer(base64.StdEncoding, errWriter{writeErr}, iter.Of(1, 2, 3))
+	assert.Equal(t, 1, n)
+	assert.Equal(t, writeErr, err)
+}
+
+func TestToGzipWriter(t *testing.T) {
+	var (
+		f    = NewFinisher()
+		buf  = &bytes.Buffer{}
+		data = []byte(strings.Repeat("hello, world\n", 10000))
+	)
+
+	// Round trip through GunzipBytes
+	n, err := f.ToGzipWriter(buf, iter.OfElements(data))
+	assert.Equal(t, buf.Len(), n)
+	assert.Nil(t, err)
+	assert.True(t, n < len(data))
+	assert.Equal(t, data, GunzipBytes()()(iter.OfElements(buf.Bytes())).ToSliceOf(byte(0)))
+
+	// Empty source is still a valid gzip stream
+	buf.Reset()
+	n, err = f.ToGzipWriter(buf, iter.Of())
+	assert.Equal(t, buf.Len(), n)
+	assert.Nil(t, err)
+	r, err := gzip.NewReader(buf)
+	assert.Nil(t, err)
+	decompressed, err := ioutil.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{}, decompressed)
+
+	// Write error
+	writeErr := fmt.Errorf("write failed")
+	n, err = f.ToGzipWriter(errWriter{writeErr}, iter.Of(1, 2, 3))
+	assert.Equal(t, 1, n)
+	assert.Equal(t, writeErr, err)
+}
+
+func TestToNDJSONWriter(t *testing.T) {
+	var (
+		f    = NewFinisher()
+		buf  = &bytes.Buffer{}
+		data = []interface{}{
+			map[string]interface{}{"a": json.Number("1")},
+			map[string]interface{}{"b": "two", "c": []interface{}{json.Number("3"), true}},
+		}
+	)
+
+	// Empty
+	n, err := f.ToNDJSONWriter(buf, iter.Of())
+	assert.Equal(t, 0, n)
+	assert.Nil(t, err)
+
+	// Round trip through FromNDJSON
+	n, err = f.ToNDJSONWriter(buf, iter.Of(data...))
+	assert.Equal(t, buf.Len(), n)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"a\":1}\n{\"b\":\"two\",\"c\":[3,true]}\n", buf.String())
+	assert.Equal(t, data, FromNDJSON(JSONNumAsNumber)()(iter.OfElements(buf.Bytes())).ToSlice())
+
+	// Encoding error
+	_, err = f.ToNDJSONWriter(buf, iter.Of(func() {}))
+	assert.NotNil(t, err)
+
+	// Write error
+	writeErr := fmt.Errorf("write failed")
+	n, err = f.ToNDJSONWriter(errWriter{writeErr}, iter.Of(1, 2, 3))
+	assert.Equal(t, 1, n)
+	assert.Equal(t, writeErr, err)
+}
+
 // ==== Continuation
 
 func TestFinisherStream(t *testing.T) {
@@ -453,6 +1735,35 @@ func TestFinisherStream(t *testing.T) {
 	assert.Equal(t, []interface{}{1, 2}, s.Iter(iter.Of(1, 2)).ToSlice())
 }
 
+func TestFinisherAndThenReader(t *testing.T) {
+	var (
+		f    = NewFinisher().Skip(1)
+		data = []byte("hello, world")
+		buf  = &bytes.Buffer{}
+	)
+
+	f.ToByteWriter(buf, iter.OfElements(data))
+
+	// Reader yields the same bytes as ToByteWriter
+	result, err := ioutil.ReadAll(f.AndThenReader(iter.OfElements(data)))
+	assert.Nil(t, err)
+	assert.Equal(t, buf.Bytes(), result)
+
+	result, err = ioutil.ReadAll(f.AndThenReader(iter.OfElements(data), ParallelConfig{}))
+	assert.Nil(t, err)
+	assert.Equal(t, buf.Bytes(), result)
+
+	// Elements are converted via ByteValue
+	result, err = ioutil.ReadAll(NewFinisher().AndThenReader(iter.Of(1, 2, 3)))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, result)
+
+	// Empty source
+	result, err = ioutil.ReadAll(NewFinisher().AndThenReader(iter.Of()))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{}, result)
+}
+
 // ==== Sequence
 
 func TestSequence(t *testing.T) {
@@ -514,6 +1825,129 @@ func TestParallel(t *testing.T) {
 	assert.Equal(t, doubledDistinct, f.ToSliceOf(0, itgen(), ParallelConfig{}))
 }
 
+func TestParallelAutoGoroutines(t *testing.T) {
+	// Pretend there are 3 CPUs
+	defer func(f func() int) { numCPU = f }(numCPU)
+	numCPU = func() int { return 3 }
+
+	// Count the number of goroutines by counting how many times the transform is invoked
+	var (
+		mtx        sync.Mutex
+		goroutines int
+		f          = New().Transform(func(it *iter.Iter) *iter.Iter {
+			mtx.Lock()
+			goroutines++
+			mtx.Unlock()
+
+			return it
+		}).AndFinish()
+	)
+
+	// More items than CPUs is split into one goroutine per CPU, regardless of NumberOfItems
+	assert.Equal(
+		t,
+		[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		f.ToSliceOf(0, iter.Of(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), ParallelConfig{NumberOfItems: 5, Flags: AutoGoroutines}),
+	)
+	assert.Equal(t, 3, goroutines)
+
+	// Fewer items than CPUs is split into one goroutine per item
+	goroutines = 0
+	assert.Equal(t, []int{1, 2}, f.ToSliceOf(0, iter.Of(1, 2), ParallelConfig{Flags: AutoGoroutines}))
+	assert.Equal(t, 2, goroutines)
+}
+
+func TestParallelProgress(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		counts   []int
+		progress = func(count int) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			counts = append(counts, count)
+		}
+		reported = func() int {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			return len(counts)
+		}
+		source = func(n int) *iter.Iter {
+			data := make([]interface{}, n)
+			for i := range data {
+				data[i] = i
+			}
+
+			return iter.Of(data...)
+		}
+	)
+
+	// One goroutine, 1000 elements with a cadence of 100.
+	// The transform records how many times progress has been reported before each element is mapped,
+	// which shows progress is reported while the elements are processed, not after they have all been collected.
+	var (
+		seen = make([]int, 1000)
+		f    = New().Map(func(element interface{}) interface{} {
+			seen[element.(int)] = reported()
+			return element
+		}).AndFinish()
+	)
+
+	assert.Equal(t, 1000, f.Count(source(1000), ParallelConfig{NumberOfItems: 1, Progress: progress, ProgressInterval: 100}))
+	assert.Equal(t, []int{100, 200, 300, 400, 500, 600, 700, 800, 900, 1000}, counts)
+	assert.Equal(t, 0, seen[0])
+	assert.Equal(t, 0, seen[98])
+	assert.Equal(t, 1, seen[99])
+	assert.Equal(t, 5, seen[500])
+	assert.Equal(t, 10, seen[999])
+
+	// Several goroutines count with a shared counter, the final count that is not a multiple of the cadence is reported last
+	counts = nil
+	assert.Equal(t, 250, f.Count(source(250), ParallelConfig{NumberOfItems: 4, Progress: progress, ProgressInterval: 100}))
+	assert.Equal(t, 3, len(counts))
+	assert.ElementsMatch(t, []int{100, 200}, counts[:2])
+	assert.Equal(t, 250, counts[2])
+
+	// Without transforms, the elements are counted as the source is read, with the default cadence
+	counts = nil
+	assert.Equal(t, 2500, NewFinisher().Count(source(2500), ParallelConfig{Progress: progress}))
+	assert.Equal(t, []int{1000, 2000, 2500}, counts)
+
+	// A short-circuiting terminal still has a final count, as all elements are processed before the terminal reads them
+	counts = nil
+	assert.Equal(t, 0, f.First(source(150), ParallelConfig{Progress: progress, ProgressInterval: 100}).MustGet())
+	assert.Equal(t, []int{100, 150}, counts)
+
+	// Empty
+	counts = nil
+	assert.Equal(t, 0, f.Count(source(0), ParallelConfig{Progress: progress}))
+	assert.Equal(t, []int(nil), counts)
+
+	// Serial, 1000 elements with a cadence of 100, counted as the terminal reads them
+	counts = nil
+	seen = make([]int, 1000)
+	assert.Equal(t, 1000, f.Count(source(1000), ParallelConfig{Flags: Serial, Progress: progress, ProgressInterval: 100}))
+	assert.Equal(t, []int{100, 200, 300, 400, 500, 600, 700, 800, 900, 1000}, counts)
+	assert.Equal(t, 0, seen[99])
+	assert.Equal(t, 1, seen[100])
+	assert.Equal(t, 9, seen[999])
+
+	// Serial, final count that is not a multiple of the cadence, with the default cadence
+	counts = nil
+	assert.Equal(t, 2500, NewFinisher().Count(source(2500), ParallelConfig{Flags: Serial, Progress: progress}))
+	assert.Equal(t, []int{1000, 2000, 2500}, counts)
+
+	// Serial applies the transform once, rather than once per goroutine
+	var goroutines int
+	f = New().Transform(func(it *iter.Iter) *iter.Iter {
+		goroutines++
+		return it
+	}).AndFinish()
+	assert.Equal(t, []int{1, 2, 3}, f.ToSliceOf(0, iter.Of(1, 2, 3), ParallelConfig{Flags: Serial}))
+	assert.Equal(t, 1, goroutines)
+}
+
 func TestThreadedReuse(t *testing.T) {
 	var (
 		f     = New().Filter(func(v interface{}) bool { return v.(int) > 5 }).AndFinish().Sort(funcs.IntSortFunc)
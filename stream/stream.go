@@ -3,11 +3,20 @@
 package stream
 
 import (
+	"container/heap"
 	"reflect"
+	"sync"
+	"time"
 
+	"github.com/bantling/gomicro/funcs"
 	"github.com/bantling/gomicro/iter"
 )
 
+var (
+	// sleep pauses between attempts of MapRetryBackoff, tests may replace it
+	sleep = time.Sleep
+)
+
 // ==== Functions
 
 // composeTransforms composes two func(*Iter) *Iter f1, f2 and returns a composition func(x *Iter) *Iter of f2(f1(x)).
@@ -75,6 +84,89 @@ func Iterate(seed interface{}, f func(interface{}) interface{}) *iter.Iter {
 	)
 }
 
+// mergeSortedHead is the current head element of one of the Iters being merged by MergeSorted
+type mergeSortedHead struct {
+	value interface{}
+	index int
+	it    *iter.Iter
+}
+
+// mergeSortedHeap is a heap.Interface of the head elements of the Iters being merged by MergeSorted.
+// Heads that compare equal are ordered by the index of their Iter, so that the merge is stable.
+type mergeSortedHeap struct {
+	less  func(e1, e2 interface{}) bool
+	heads []mergeSortedHead
+}
+
+func (h *mergeSortedHeap) Len() int { return len(h.heads) }
+
+func (h *mergeSortedHeap) Less(i, j int) bool {
+	hi, hj := h.heads[i], h.heads[j]
+	if h.less(hi.value, hj.value) {
+		return true
+	}
+
+	if h.less(hj.value, hi.value) {
+		return false
+	}
+
+	return hi.index < hj.index
+}
+
+func (h *mergeSortedHeap) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *mergeSortedHeap) Push(x interface{}) { h.heads = append(h.heads, x.(mergeSortedHead)) }
+
+func (h *mergeSortedHeap) Pop() interface{} {
+	n := len(h.heads) - 1
+	head := h.heads[n]
+	h.heads = h.heads[:n]
+	return head
+}
+
+// MergeSorted performs a k-way merge of Iters that are each already sorted according to less, using a heap.
+// The result is an Iter of all elements of all the Iters in sorted order, without having to sort them all again.
+// Elements that compare equal are iterated in the order of the Iters they came from.
+// The Iters are read lazily, only one element of each Iter is buffered at a time.
+func MergeSorted(less func(e1, e2 interface{}) bool, iters ...*iter.Iter) *iter.Iter {
+	var (
+		h           = &mergeSortedHeap{less: less}
+		initialized bool
+	)
+
+	return iter.New(
+		func() (interface{}, bool) {
+			// Read the first element of each Iter on first call
+			if !initialized {
+				initialized = true
+
+				for i, it := range iters {
+					if it.Next() {
+						h.heads = append(h.heads, mergeSortedHead{value: it.Value(), index: i, it: it})
+					}
+				}
+
+				heap.Init(h)
+			}
+
+			if h.Len() == 0 {
+				return nil, false
+			}
+
+			// The smallest head is the next value; replace it with the next element of the same Iter, if any
+			head := h.heads[0]
+			if head.it.Next() {
+				h.heads[0].value = head.it.Value()
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+
+			return head.value, true
+		},
+	)
+}
+
 // ==== Stream
 
 // Stream is based on a composed transform, and provides a streaming facility where items can be transformed one by one as they are iterated into a new set, and possibly apply further transforms on the new set.
@@ -155,6 +247,33 @@ func (s Stream) Filter(f func(element interface{}) bool) Stream {
 	)
 }
 
+// FilterAny filters the stream with fn, where fn is any func of one argument that returns a bool, such as a func(int) bool.
+// fn is adapted with funcs.Filter, so each element is converted to the argument type of fn.
+// Panics if fn is not a func of one argument that returns a bool.
+func (s Stream) FilterAny(fn interface{}) Stream {
+	return s.Filter(funcs.Filter(fn))
+}
+
+// FilterMap returns a new stream of elements mapped by the given function, which also decides whether each element is kept.
+// f returns the mapped value and a keep flag; elements for which keep is false are dropped.
+func (s Stream) FilterMap(f func(element interface{}) (interface{}, bool)) Stream {
+	return s.Transform(
+		func(it *iter.Iter) *iter.Iter {
+			return iter.New(
+				func() (interface{}, bool) {
+					for it.Next() {
+						if val, keep := f(it.Value()); keep {
+							return val, true
+						}
+					}
+
+					return nil, false
+				},
+			)
+		},
+	)
+}
+
 // FilterNot returns a new stream of all elements that do not pass the given predicate
 func (s Stream) FilterNot(f func(element interface{}) bool) Stream {
 	return s.Filter(
@@ -181,6 +300,68 @@ func (s Stream) Map(f func(element interface{}) interface{}) Stream {
 	)
 }
 
+// MapAny maps each element with fn, where fn is any func of one argument and one result, such as a func(int) string.
+// fn is adapted with funcs.Map, so each element is converted to the argument type of fn.
+// Panics if fn is not a func of one argument and one result.
+func (s Stream) MapAny(fn interface{}) Stream {
+	return s.Map(funcs.Map(fn))
+}
+
+// MapByType maps each element with the handler for the dynamic type of the element.
+// If there is no handler for the type, the element is mapped with fallback, or passed through as is if fallback is nil.
+// A nil element has a nil reflect.Type, so it can only be handled by a handler with a nil key, or the fallback.
+func (s Stream) MapByType(
+	handlers map[reflect.Type]func(element interface{}) interface{},
+	fallback func(element interface{}) interface{},
+) Stream {
+	return s.Map(
+		func(element interface{}) interface{} {
+			if handler, haveIt := handlers[reflect.TypeOf(element)]; haveIt {
+				return handler(element)
+			}
+
+			if fallback != nil {
+				return fallback(element)
+			}
+
+			return element
+		},
+	)
+}
+
+// MapCached maps each element with f, caching the result by keyFn(element), so that f is only called once for each key.
+// The cache is shared by every iteration of the resulting Stream, including Streams and Finishers built from it,
+// and it is safe for concurrent use, although f may be called more than once for a key that is mapped concurrently.
+// Keys must be a type compatible with a map key.
+// The cache is never evicted, so it grows by one entry for each distinct key - if the number of distinct keys is unbounded,
+// use Map with f wrapped in a bounded cache instead.
+func (s Stream) MapCached(keyFn func(element interface{}) interface{}, f func(element interface{}) interface{}) Stream {
+	var (
+		mutex sync.Mutex
+		cache = map[interface{}]interface{}{}
+	)
+
+	return s.Map(
+		func(element interface{}) interface{} {
+			key := keyFn(element)
+
+			mutex.Lock()
+			result, haveIt := cache[key]
+			mutex.Unlock()
+
+			if !haveIt {
+				result = f(element)
+
+				mutex.Lock()
+				cache[key] = result
+				mutex.Unlock()
+			}
+
+			return result
+		},
+	)
+}
+
 // MapIf maps each element that matches the predicate to a new element.
 // Elements that do not match the predicate remain as is.
 // The matching elements should generally not be mapped to a new type, as that would produce different types in the resulting Stream.
@@ -205,6 +386,40 @@ func (s Stream) MapIf(p func(element interface{}) bool, f func(element interface
 	)
 }
 
+// MapRetryBackoff maps each element with f, retrying f up to the given number of attempts until it succeeds.
+// The first retry sleeps for base, and each subsequent retry sleeps for twice as long as the previous retry.
+// EG, if attempts is 4, and base is 1 second, then the retries sleep for 1, 2, and 4 seconds.
+// Panics with the last error returned by f if all attempts fail.
+// Panics with ErrInvalidAttempts if attempts <= 0.
+func (s Stream) MapRetryBackoff(attempts int, base time.Duration, f func(element interface{}) (interface{}, error)) Stream {
+	if attempts <= 0 {
+		panic(ErrInvalidAttempts)
+	}
+
+	return s.Map(
+		func(element interface{}) interface{} {
+			var (
+				result interface{}
+				err    error
+				delay  = base
+			)
+
+			for attempt := 1; ; attempt++ {
+				if result, err = f(element); err == nil {
+					return result
+				}
+
+				if attempt == attempts {
+					panic(err)
+				}
+
+				sleep(delay)
+				delay *= 2
+			}
+		},
+	)
+}
+
 // Peek returns a stream that calls a function that examines each value and performs an additional operation
 func (s Stream) Peek(f func(interface{})) Stream {
 	return s.Transform(
@@ -224,6 +439,29 @@ func (s Stream) Peek(f func(interface{})) Stream {
 	)
 }
 
+// Require returns a stream that passes each element through unchanged, but panics with msgFn(element) for the first element that fails the predicate.
+// Unlike Filter, which drops elements that fail the predicate, Require aborts the stream.
+func (s Stream) Require(pred func(element interface{}) bool, msgFn func(element interface{}) string) Stream {
+	return s.Transform(
+		func(it *iter.Iter) *iter.Iter {
+			return iter.New(
+				func() (interface{}, bool) {
+					if it.Next() {
+						val := it.Value()
+						if !pred(val) {
+							panic(msgFn(val))
+						}
+
+						return val, true
+					}
+
+					return nil, false
+				},
+			)
+		},
+	)
+}
+
 //
 // ==== Terminals
 //
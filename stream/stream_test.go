@@ -4,8 +4,11 @@ package stream
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/bantling/gomicro/funcs"
 	"github.com/bantling/gomicro/iter"
@@ -231,6 +234,36 @@ func TestIterate(t *testing.T) {
 	assert.Equal(t, 8, iter.NextIntValue())
 }
 
+func TestMergeSorted(t *testing.T) {
+	// No iters
+	assert.Equal(t, []interface{}{}, MergeSorted(funcs.IntSortFunc).ToSlice())
+
+	// Only empty iters
+	assert.Equal(t, []interface{}{}, MergeSorted(funcs.IntSortFunc, iter.Of(), iter.Of()).ToSlice())
+
+	// Three sorted iters with empty iters among them
+	assert.Equal(
+		t,
+		[]int{1, 2, 2, 3, 4, 5, 6, 7, 8, 9},
+		MergeSorted(
+			funcs.IntSortFunc,
+			iter.Of(),
+			iter.Of(1, 4, 7),
+			iter.Of(2, 5, 8, 9),
+			iter.Of(),
+			iter.Of(2, 3, 6),
+		).ToSliceOf(0),
+	)
+
+	// Equal elements are iterated in order of the iters they came from
+	less := func(e1, e2 interface{}) bool { return e1.(string)[0] < e2.(string)[0] }
+	assert.Equal(
+		t,
+		[]string{"a1", "a2", "b2", "b3", "c1"},
+		MergeSorted(less, iter.Of("a1", "c1"), iter.Of("a2", "b2"), iter.Of("b3")).ToSliceOf(""),
+	)
+}
+
 // ==== Constructors
 
 func TestStreamZeroValue(t *testing.T) {
@@ -271,6 +304,43 @@ func TestStreamFilter(t *testing.T) {
 	assert.Equal(t, []int{1, 2}, s.Iter(iter.Of(1, 2, 3)).ToSliceOf(0))
 }
 
+func TestStreamFilterAny(t *testing.T) {
+	var (
+		fn   = func(element int) bool { return element%2 == 1 }
+		s    = New().FilterAny(fn)
+		data = []interface{}{1, 2, 3, 4, 5}
+	)
+
+	assert.Equal(t, []interface{}{}, s.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{1, 3, 5}, s.Iter(iter.Of(data...)).ToSlice())
+
+	// Same as wrapping with funcs.Filter manually
+	assert.Equal(t, New().Filter(funcs.Filter(fn)).Iter(iter.Of(data...)).ToSlice(), s.Iter(iter.Of(data...)).ToSlice())
+
+	func() {
+		defer func() {
+			assert.NotNil(t, recover())
+		}()
+
+		New().FilterAny(func(element int) int { return element })
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestStreamFilterMap(t *testing.T) {
+	fn := func(element interface{}) (interface{}, bool) {
+		if i := element.(int); i%2 == 0 {
+			return i * 2, true
+		}
+
+		return nil, false
+	}
+	s := New().FilterMap(fn)
+	assert.Equal(t, []interface{}{}, s.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{}, s.Iter(iter.Of(1, 3)).ToSlice())
+	assert.Equal(t, []interface{}{4, 8}, s.Iter(iter.Of(1, 2, 3, 4, 5)).ToSlice())
+}
+
 func TestStreamFilterNot(t *testing.T) {
 	fn := func(element interface{}) bool { return element.(int) < 3 }
 	s := New().FilterNot(fn)
@@ -292,6 +362,77 @@ func TestStreamMap(t *testing.T) {
 	assert.Equal(t, []string{"2", "4"}, s.Iter(iter.Of(1, 2)).ToSliceOf(""))
 }
 
+func TestStreamMapAny(t *testing.T) {
+	s := New().MapAny(func(element int) int { return element * 2 })
+	assert.Equal(t, []interface{}{}, s.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{2, 4, 6}, s.Iter(iter.Of(1, 2, 3)).ToSlice())
+
+	// Elements are converted to the argument type, and the result type can differ
+	s = New().MapAny(func(element int) string { return strconv.Itoa(element) })
+	assert.Equal(t, []interface{}{"1", "2"}, s.Iter(iter.Of(int8(1), uint(2))).ToSlice())
+
+	func() {
+		defer func() {
+			assert.NotNil(t, recover())
+		}()
+
+		New().MapAny(func(element1, element2 int) int { return element1 })
+		assert.Fail(t, "Must panic")
+	}()
+}
+
+func TestStreamMapByType(t *testing.T) {
+	var (
+		handlers = map[reflect.Type]func(element interface{}) interface{}{
+			reflect.TypeOf(0):  func(element interface{}) interface{} { return element.(int) * 2 },
+			reflect.TypeOf(""): func(element interface{}) interface{} { return strings.ToUpper(element.(string)) },
+		}
+		fallback = func(element interface{}) interface{} { return fmt.Sprintf("other %v", element) }
+		data     = iter.Of(1, "a", 2.5, 3, "b")
+	)
+
+	assert.Equal(t, []interface{}{}, New().MapByType(handlers, fallback).Iter(iter.Of()).ToSlice())
+	assert.Equal(
+		t,
+		[]interface{}{2, "A", "other 2.5", 6, "B"},
+		New().MapByType(handlers, fallback).Iter(data).ToSlice(),
+	)
+
+	// Nil fallback passes unhandled elements through
+	assert.Equal(
+		t,
+		[]interface{}{2, "A", 2.5, nil},
+		New().MapByType(handlers, nil).Iter(iter.Of(1, "a", 2.5, nil)).ToSlice(),
+	)
+}
+
+func TestStreamMapCached(t *testing.T) {
+	var (
+		calls = map[interface{}]int{}
+		keyFn = func(element interface{}) interface{} { return element.(int) % 10 }
+		fn    = func(element interface{}) interface{} {
+			calls[keyFn(element)]++
+			return element.(int) * 2
+		}
+		s = New().MapCached(keyFn, fn)
+	)
+
+	assert.Equal(t, []interface{}{}, s.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{2, 4, 6}, s.Iter(iter.Of(1, 2, 3)).ToSlice())
+
+	// Overlapping keys use the results cached by the first call, 11 has the same key as 1
+	assert.Equal(t, []interface{}{4, 6, 8, 2}, s.Iter(iter.Of(2, 3, 4, 11)).ToSlice())
+	assert.Equal(t, map[interface{}]int{1: 1, 2: 1, 3: 1, 4: 1}, calls)
+
+	// The cache is shared by Finishers built from the Stream
+	assert.Equal(t, []interface{}{2, 4, 10}, s.AndFinish().ToSlice(iter.Of(1, 2, 5)))
+	assert.Equal(t, map[interface{}]int{1: 1, 2: 1, 3: 1, 4: 1, 5: 1}, calls)
+
+	// Each call to MapCached has a separate cache
+	assert.Equal(t, []interface{}{2}, New().MapCached(keyFn, fn).Iter(iter.Of(1)).ToSlice())
+	assert.Equal(t, 2, calls[1])
+}
+
 func TestStreamMapIf(t *testing.T) {
 	test := func(element interface{}) bool {
 		return element.(int) > 3
@@ -307,6 +448,59 @@ func TestStreamMapIf(t *testing.T) {
 	assert.Equal(t, []interface{}{2, 8}, s.Iter(iter.Of(2, 4)).ToSlice())
 }
 
+func TestStreamMapRetryBackoff(t *testing.T) {
+	var sleeps []time.Duration
+	origSleep := sleep
+	defer func() { sleep = origSleep }()
+	sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	// f fails until the given number of calls have been made for an element
+	var (
+		calls   = map[interface{}]int{}
+		failErr = fmt.Errorf("fail")
+		f       = func(succeedOn int) func(element interface{}) (interface{}, error) {
+			return func(element interface{}) (interface{}, error) {
+				if calls[element]++; calls[element] < succeedOn {
+					return nil, failErr
+				}
+
+				return element.(int) * 2, nil
+			}
+		}
+	)
+
+	// Success on first attempt does not sleep
+	assert.Equal(t, []interface{}{2, 4}, New().MapRetryBackoff(3, time.Second, f(1)).Iter(iter.Of(1, 2)).ToSlice())
+	assert.Nil(t, sleeps)
+
+	// Success on a later attempt
+	calls = map[interface{}]int{}
+	assert.Equal(t, []interface{}{6}, New().MapRetryBackoff(4, time.Second, f(4)).Iter(iter.Of(3)).ToSlice())
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, sleeps)
+
+	// All attempts fail
+	calls, sleeps = map[interface{}]int{}, nil
+	func() {
+		defer func() {
+			assert.Equal(t, failErr, recover())
+			assert.Equal(t, 2, calls[5])
+			assert.Equal(t, []time.Duration{10 * time.Millisecond}, sleeps)
+		}()
+
+		New().MapRetryBackoff(2, 10*time.Millisecond, f(3)).Iter(iter.Of(5)).Next()
+		assert.Fail(t, "Must panic")
+	}()
+
+	func() {
+		defer func() {
+			assert.Equal(t, ErrInvalidAttempts, recover())
+		}()
+
+		New().MapRetryBackoff(0, time.Second, f(1))
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 func TestStreamPeek(t *testing.T) {
 	var elements []interface{}
 	fn := func(element interface{}) {
@@ -331,6 +525,30 @@ func TestStreamPeek(t *testing.T) {
 	assert.Equal(t, elements2, []int{1, 2})
 }
 
+func TestStreamRequire(t *testing.T) {
+	var (
+		pred  = func(element interface{}) bool { return element.(int) > 0 }
+		msgFn = func(element interface{}) string { return fmt.Sprintf("%d is not positive", element) }
+		s     = New().Require(pred, msgFn)
+	)
+
+	assert.Equal(t, []interface{}{}, s.Iter(iter.Of()).ToSlice())
+	assert.Equal(t, []interface{}{1, 2, 3}, s.Iter(iter.Of(1, 2, 3)).ToSlice())
+
+	// Elements before the offending element are iterated
+	it := s.Iter(iter.Of(1, -2, 3))
+	assert.Equal(t, 1, it.NextValue())
+
+	func() {
+		defer func() {
+			assert.Equal(t, "-2 is not positive", recover())
+		}()
+
+		it.NextValue()
+		assert.Fail(t, "Must panic")
+	}()
+}
+
 // ==== Continuation
 
 func TestStreamIter(t *testing.T) {